@@ -0,0 +1,165 @@
+package cartographer
+
+import (
+	"sort"
+)
+
+// BlastRadius finds every workspace that uses the module with the given source at a version satisfying constraint, such
+// as "< 3.0.0", and would therefore be affected by upgrading to a new release. An empty constraint matches every
+// version. Module usage is fetched with a source filter and workspaces with a module count filter.
+func (c *Cartographer) BlastRadius(source, constraint string) (*BlastRadiusReport, error) {
+	modules, err := c.Modules([]ModuleFilter{{
+		Type:     ModuleSource,
+		Operator: Contains,
+		Value:    source,
+	}})
+	if err != nil {
+		return nil, err
+	}
+
+	workspaces, err := c.Workspaces([]WorkspaceFilter{{
+		Type:     WorkspaceModuleCount,
+		Operator: Gt,
+		Value:    "0",
+	}})
+	if err != nil {
+		return nil, err
+	}
+
+	return NewBlastRadiusReport(source, constraint, modules, workspaces)
+}
+
+// NewBlastRadiusReport joins module usage with workspaces to list the workspaces using the module with the given source
+// at a version satisfying constraint. Submodules of the module are included. When a constraint is given, module versions
+// that cannot be parsed are not considered affected. It returns an error if the constraint is invalid.
+func NewBlastRadiusReport(source, constraint string, modules []Module, workspaces []Workspace) (*BlastRadiusReport, error) {
+	var c versionConstraint
+	if constraint != "" {
+		var err error
+		if c, err = parseConstraint(constraint); err != nil {
+			return nil, err
+		}
+	}
+
+	byName := make(map[string]Workspace)
+	for _, w := range workspaces {
+		byName[w.WorkspaceName] = w
+	}
+
+	target := normalizeModuleSource(source)
+	impacted := make(map[string]*ImpactedWorkspace)
+	for _, m := range modules {
+		if normalizeModuleSource(m.Source) != target {
+			continue
+		}
+		if c != nil {
+			v, err := parseSemver(m.Version)
+			if err != nil || !c.check(v) {
+				continue
+			}
+		}
+
+		for _, name := range m.WorkspaceNames() {
+			iw, ok := impacted[name]
+			if !ok {
+				w := byName[name]
+				iw = &ImpactedWorkspace{
+					WorkspaceName:                name,
+					ExternalId:                   w.ExternalId,
+					ProjectName:                  w.ProjectName,
+					WorkspaceTerraformVersion:    w.WorkspaceTerraformVersion,
+					StateVersionTerraformVersion: w.StateVersionTerraformVersion,
+				}
+				if w.VcsRepoIdentifier != nil {
+					iw.VcsRepoIdentifier = *w.VcsRepoIdentifier
+				}
+				impacted[name] = iw
+			}
+			iw.ModuleVersions = appendUnique(iw.ModuleVersions, m.Version)
+		}
+	}
+
+	report := &BlastRadiusReport{Source: source, Constraint: constraint}
+	projects := make(map[string][]ImpactedWorkspace)
+	repos := make(map[string][]ImpactedWorkspace)
+	for _, iw := range impacted {
+		sort.Slice(iw.ModuleVersions, func(i, j int) bool {
+			return compareVersions(iw.ModuleVersions[i], iw.ModuleVersions[j]) > 0
+		})
+		report.Workspaces = append(report.Workspaces, *iw)
+	}
+
+	sortImpactedWorkspaces(report.Workspaces)
+	for _, iw := range report.Workspaces {
+		projects[iw.ProjectName] = append(projects[iw.ProjectName], iw)
+		repos[iw.VcsRepoIdentifier] = append(repos[iw.VcsRepoIdentifier], iw)
+	}
+	report.ByProject = blastRadiusGroups(projects)
+	report.ByVcsRepo = blastRadiusGroups(repos)
+
+	return report, nil
+}
+
+// BlastRadiusReport lists the workspaces affected by a module upgrade, all together and grouped by project and VCS
+// repository.
+type BlastRadiusReport struct {
+	Source     string              `json:"source"`
+	Constraint string              `json:"constraint"`
+	Workspaces []ImpactedWorkspace `json:"workspaces"`
+	ByProject  []BlastRadiusGroup  `json:"by-project"`
+	ByVcsRepo  []BlastRadiusGroup  `json:"by-vcs-repo"`
+}
+
+// BlastRadiusGroup holds the affected workspaces sharing a project or VCS repository. Name is empty for workspaces
+// without a VCS repository.
+type BlastRadiusGroup struct {
+	Name       string              `json:"name"`
+	Workspaces []ImpactedWorkspace `json:"workspaces"`
+}
+
+// ImpactedWorkspace represents a workspace affected by a module upgrade. ModuleVersions lists every affected version
+// of the module the workspace uses, newest first.
+type ImpactedWorkspace struct {
+	WorkspaceName                string   `json:"workspace-name"`
+	ExternalId                   string   `json:"external-id"`
+	ProjectName                  string   `json:"project-name"`
+	VcsRepoIdentifier            string   `json:"vcs-repo-identifier"`
+	ModuleVersions               []string `json:"module-versions"`
+	WorkspaceTerraformVersion    string   `json:"workspace-terraform-version"`
+	StateVersionTerraformVersion string   `json:"state-version-terraform-version"`
+}
+
+// blastRadiusGroups converts grouped workspaces into groups sorted by the number of workspaces, highest first.
+func blastRadiusGroups(grouped map[string][]ImpactedWorkspace) []BlastRadiusGroup {
+	var groups []BlastRadiusGroup
+	for name, workspaces := range grouped {
+		groups = append(groups, BlastRadiusGroup{Name: name, Workspaces: workspaces})
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if len(groups[i].Workspaces) != len(groups[j].Workspaces) {
+			return len(groups[i].Workspaces) > len(groups[j].Workspaces)
+		}
+		return groups[i].Name < groups[j].Name
+	})
+	return groups
+}
+
+// sortImpactedWorkspaces sorts workspaces by project and then by name.
+func sortImpactedWorkspaces(workspaces []ImpactedWorkspace) {
+	sort.Slice(workspaces, func(i, j int) bool {
+		if workspaces[i].ProjectName != workspaces[j].ProjectName {
+			return workspaces[i].ProjectName < workspaces[j].ProjectName
+		}
+		return workspaces[i].WorkspaceName < workspaces[j].WorkspaceName
+	})
+}
+
+// appendUnique appends s to list unless it is already present.
+func appendUnique(list []string, s string) []string {
+	for _, item := range list {
+		if item == s {
+			return list
+		}
+	}
+	return append(list, s)
+}
@@ -0,0 +1,53 @@
+package cartographer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewBlastRadiusReport(t *testing.T) {
+	repo := "github.com/majesticbeast/network"
+	workspaces := []Workspace{
+		{WorkspaceName: "network-prod", ExternalId: "ws-1", ProjectName: "network", VcsRepoIdentifier: &repo, WorkspaceTerraformVersion: "1.6.0"},
+		{WorkspaceName: "network-dev", ExternalId: "ws-2", ProjectName: "network", VcsRepoIdentifier: &repo, WorkspaceTerraformVersion: "1.5.0"},
+		{WorkspaceName: "app", ExternalId: "ws-3", ProjectName: "apps", WorkspaceTerraformVersion: "1.6.0"},
+	}
+	modules := []Module{
+		{Source: "app.terraform.io/myOrgName/vpc/aws", Version: "2.1.0", Workspaces: "network-prod,app"},
+		{Source: "app.terraform.io/myOrgName/vpc/aws//modules/subnets", Version: "2.0.0", Workspaces: "network-prod"},
+		{Source: "app.terraform.io/myOrgName/vpc/aws", Version: "3.0.0", Workspaces: "network-dev"},
+		{Source: "app.terraform.io/myOrgName/vpc-endpoints/aws", Version: "1.0.0", Workspaces: "network-dev"},
+	}
+
+	report, err := NewBlastRadiusReport("app.terraform.io/myOrgName/vpc/aws", "< 3.0.0", modules, workspaces)
+	if err != nil {
+		t.Fatalf("NewBlastRadiusReport() returned an error: %v", err)
+	}
+
+	expected := []ImpactedWorkspace{
+		{WorkspaceName: "app", ExternalId: "ws-3", ProjectName: "apps", ModuleVersions: []string{"2.1.0"}, WorkspaceTerraformVersion: "1.6.0"},
+		{WorkspaceName: "network-prod", ExternalId: "ws-1", ProjectName: "network", VcsRepoIdentifier: repo, ModuleVersions: []string{"2.1.0", "2.0.0"}, WorkspaceTerraformVersion: "1.6.0"},
+	}
+	if !reflect.DeepEqual(report.Workspaces, expected) {
+		t.Errorf("NewBlastRadiusReport() returned %+v, expected %+v", report.Workspaces, expected)
+	}
+
+	if len(report.ByProject) != 2 || report.ByProject[0].Name != "apps" {
+		t.Errorf("NewBlastRadiusReport() returned projects %+v", report.ByProject)
+	}
+	if len(report.ByVcsRepo) != 2 || report.ByVcsRepo[1].Name != repo {
+		t.Errorf("NewBlastRadiusReport() returned vcs repos %+v", report.ByVcsRepo)
+	}
+
+	all, err := NewBlastRadiusReport("app.terraform.io/myOrgName/vpc/aws", "", modules, workspaces)
+	if err != nil {
+		t.Fatalf("NewBlastRadiusReport() returned an error: %v", err)
+	}
+	if len(all.Workspaces) != 3 {
+		t.Errorf("NewBlastRadiusReport() without a constraint returned %v workspaces, expected 3", len(all.Workspaces))
+	}
+
+	if _, err := NewBlastRadiusReport("app.terraform.io/myOrgName/vpc/aws", "<", modules, workspaces); err == nil {
+		t.Errorf("NewBlastRadiusReport() with an invalid constraint expected an error")
+	}
+}
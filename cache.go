@@ -0,0 +1,201 @@
+package cartographer
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// CacheEntry is a response stored in a cache.
+type CacheEntry struct {
+	StoredAt time.Time   `json:"stored-at"`
+	Header   http.Header `json:"header"`
+	Body     []byte      `json:"body"`
+}
+
+// CacheStore stores cached responses by key. Implementations must be safe for concurrent use.
+type CacheStore interface {
+	// Get returns the entry stored under key, and false if there is none.
+	Get(key string) (CacheEntry, bool)
+	// Set stores an entry under key, replacing any existing entry.
+	Set(key string, entry CacheEntry) error
+}
+
+// MemoryCache is a CacheStore that keeps entries in memory for the life of the process.
+type MemoryCache struct {
+	mu      sync.RWMutex
+	entries map[string]CacheEntry
+}
+
+// NewMemoryCache creates an empty in-memory cache.
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{entries: make(map[string]CacheEntry)}
+}
+
+// Get returns the entry stored under key.
+func (m *MemoryCache) Get(key string) (CacheEntry, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	entry, ok := m.entries[key]
+	return entry, ok
+}
+
+// Set stores an entry under key.
+func (m *MemoryCache) Set(key string, entry CacheEntry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries[key] = entry
+	return nil
+}
+
+// DiskCache is a CacheStore that keeps each entry in a JSON file in a directory, so that it is shared between runs.
+type DiskCache struct {
+	dir string
+}
+
+// NewDiskCache creates a cache in dir, creating the directory if needed.
+func NewDiskCache(dir string) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &DiskCache{dir: dir}, nil
+}
+
+// Get returns the entry stored under key. Unreadable entries are treated as missing.
+func (d *DiskCache) Get(key string) (CacheEntry, bool) {
+	data, err := os.ReadFile(d.path(key))
+	if err != nil {
+		return CacheEntry{}, false
+	}
+
+	var entry CacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return CacheEntry{}, false
+	}
+	return entry, true
+}
+
+// Set stores an entry under key. The entry is written to a temporary file and renamed so that concurrent readers
+// never see a partial entry.
+func (d *DiskCache) Set(key string, entry CacheEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(d.dir, "entry.*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), d.path(key))
+}
+
+// path returns the file an entry is stored in.
+func (d *DiskCache) path(key string) string {
+	return filepath.Join(d.dir, key+".json")
+}
+
+// CacheStats counts the requests answered from the cache and those sent on.
+type CacheStats struct {
+	Hits   int64 `json:"hits"`
+	Misses int64 `json:"misses"`
+}
+
+// CachingDoer is a Doer that answers GET requests from a CacheStore while the stored response is younger than the TTL
+// and sends everything else on to the wrapped Doer. Only successful responses are stored.
+//
+// Requests are keyed by method, URL and Authorization header. The URL carries the organization, query type, filters
+// and page, and the token is included so that callers with different permissions never share entries. Keys are
+// hashed so that tokens are not written to disk.
+type CachingDoer struct {
+	next   Doer
+	store  CacheStore
+	ttl    time.Duration
+	now    func() time.Time
+	bypass atomic.Bool
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+// NewCachingDoer wraps next with a cache that keeps responses in store for ttl.
+func NewCachingDoer(next Doer, store CacheStore, ttl time.Duration) *CachingDoer {
+	return &CachingDoer{
+		next:  next,
+		store: store,
+		ttl:   ttl,
+		now:   time.Now,
+	}
+}
+
+// SetBypass controls whether cached entries are ignored. While bypassed, every request is sent on and successful
+// responses still refresh the cache.
+func (c *CachingDoer) SetBypass(bypass bool) {
+	c.bypass.Store(bypass)
+}
+
+// Stats returns the number of cache hits and misses so far.
+func (c *CachingDoer) Stats() CacheStats {
+	return CacheStats{Hits: c.hits.Load(), Misses: c.misses.Load()}
+}
+
+// Do answers the request from the cache if possible, otherwise sends it on and caches a successful response.
+func (c *CachingDoer) Do(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		return c.next.Do(req)
+	}
+
+	key := cacheKey(req)
+	if !c.bypass.Load() {
+		if entry, ok := c.store.Get(key); ok && c.now().Sub(entry.StoredAt) < c.ttl {
+			c.hits.Add(1)
+			return &http.Response{
+				Status:     "200 OK",
+				StatusCode: http.StatusOK,
+				Header:     entry.Header,
+				Body:       io.NopCloser(bytes.NewReader(entry.Body)),
+				Request:    req,
+			}, nil
+		}
+	}
+	c.misses.Add(1)
+
+	resp, err := c.next.Do(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	// Caching is best effort: a response that cannot be stored is still returned.
+	_ = c.store.Set(key, CacheEntry{StoredAt: c.now(), Header: resp.Header, Body: body})
+
+	return resp, nil
+}
+
+// cacheKey returns the hashed cache key of a request.
+func cacheKey(req *http.Request) string {
+	sum := sha256.Sum256([]byte(req.Method + " " + req.URL.String() + "\n" + req.Header.Get("Authorization")))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,114 @@
+package cartographer
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCachingDoer(t *testing.T) {
+	responseBody := `{"data": [{"attributes": {"version": "VERSION", "workspace-count": 1, "workspaces": "ws"}, "id": "1", "type": "t"}],
+		"links": {"self": "test", "first": "test", "last": "test", "prev": null, "next": null},
+		"meta": {"pagination": {"current-page": 1, "page-size": 100, "next-page": null, "prev-page": null, "total-pages": 1, "total-count": 1}}}`
+
+	requests := 0
+	version := "1.6.0"
+	mockClient := &MockClient{
+		MockDo: func(req *http.Request) (*http.Response, error) {
+			requests++
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(strings.Replace(responseBody, "VERSION", version, 1))),
+			}, nil
+		},
+	}
+
+	diskCache, err := NewDiskCache(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewDiskCache() returned an error: %v", err)
+	}
+
+	for name, store := range map[string]CacheStore{"memory": NewMemoryCache(), "disk": diskCache} {
+		requests = 0
+		version = "1.6.0"
+		now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		doer := NewCachingDoer(mockClient, store, time.Minute)
+		doer.now = func() time.Time { return now }
+		c := NewCartographerWithClient("test", "test", doer)
+
+		for i := 0; i < 2; i++ {
+			versions, err := c.TFVersions(nil)
+			if err != nil || len(versions) != 1 {
+				t.Fatalf("%s: TFVersions() returned %+v, %v", name, versions, err)
+			}
+		}
+		if requests != 1 {
+			t.Errorf("%s: sent %d requests, expected the second call to be served from the cache", name, requests)
+		}
+		if stats := doer.Stats(); stats != (CacheStats{Hits: 1, Misses: 1}) {
+			t.Errorf("%s: Stats() returned %+v, expected 1 hit and 1 miss", name, stats)
+		}
+
+		if _, err := c.TFVersions([]TFVersionFilter{{Type: TFVersionVersion, Operator: Is, Value: "1.6.0"}}); err != nil {
+			t.Fatalf("%s: TFVersions() returned an error: %v", name, err)
+		}
+		if requests != 2 {
+			t.Errorf("%s: a query with different filters was served from the cache", name)
+		}
+
+		// The cached entry is still fresh, so only a request that bypasses the cache reaches the API.
+		now = now.Add(30 * time.Second)
+		version = "1.7.0"
+		doer.SetBypass(true)
+		versions, err := c.TFVersions(nil)
+		if err != nil {
+			t.Fatalf("%s: TFVersions() returned an error: %v", name, err)
+		}
+		doer.SetBypass(false)
+		if requests != 3 || versions[0].Version != "1.7.0" {
+			t.Errorf("%s: sent %d requests and returned %+v, expected the bypassed request to reach the API", name, requests, versions)
+		}
+
+		// The original entry would have expired by now, so a hit shows the bypassed request refreshed it.
+		now = now.Add(45 * time.Second)
+		versions, err = c.TFVersions(nil)
+		if err != nil {
+			t.Fatalf("%s: TFVersions() returned an error: %v", name, err)
+		}
+		if requests != 3 || versions[0].Version != "1.7.0" {
+			t.Errorf("%s: sent %d requests and returned %+v, expected the refreshed entry from the cache", name, requests, versions)
+		}
+
+		now = now.Add(time.Minute)
+		if _, err := c.TFVersions(nil); err != nil {
+			t.Fatalf("%s: TFVersions() returned an error: %v", name, err)
+		}
+		if requests != 4 {
+			t.Errorf("%s: sent %d requests, expected an expired entry to be refetched", name, requests)
+		}
+	}
+}
+
+func TestCachingDoerSkipsErrors(t *testing.T) {
+	mockClient := &MockClient{
+		MockDo: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 500,
+				Body:       io.NopCloser(strings.NewReader("")),
+			}, nil
+		},
+	}
+
+	store := NewMemoryCache()
+	doer := NewCachingDoer(mockClient, store, time.Minute)
+	req, _ := http.NewRequest(http.MethodGet, "https://app.terraform.io/api/v2/test", nil)
+	resp, err := doer.Do(req)
+	if err != nil || resp.StatusCode != 500 {
+		t.Fatalf("Do() returned %v, %v", resp, err)
+	}
+	if len(store.entries) != 0 {
+		t.Errorf("Do() cached an error response")
+	}
+}
@@ -1,9 +1,11 @@
 package cartographer
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 )
 
@@ -12,6 +14,8 @@ const (
 	rateBuffer = 7
 )
 
+const webBaseUrl = "https://app.terraform.io/app"
+
 const (
 	Is FilterOperator = iota
 	IsNot
@@ -45,10 +49,16 @@ type Cartographer struct {
 
 // NewCartographer Creates a new Cartographer client with the given organization name and Terraform Cloud API token.
 func NewCartographer(orgName string, token string) *Cartographer {
+	return NewCartographerWithClient(orgName, token, &http.Client{
+		Timeout: time.Second * 10,
+	})
+}
+
+// NewCartographerWithClient Creates a new Cartographer client that sends its requests through the given client, such
+// as a CachingDoer wrapping an http.Client.
+func NewCartographerWithClient(orgName string, token string, client Doer) *Cartographer {
 	return &Cartographer{
-		client: &http.Client{
-			Timeout: time.Second * 10,
-		},
+		client:  client,
 		orgName: orgName,
 		token:   token,
 	}
@@ -74,21 +84,107 @@ func buildRegistryUrl(orgName string) (*url.URL, error) {
 	return baseURL, nil
 }
 
-// checkStatusCode checks the status code of the response. If the status code is 429, it returns an error indicating
-// that the request was rate limited. If the status code is not in the 200 range, it returns an error indicating
-// the status code.
+// APIError is returned when the Terraform Cloud API responds with a status code outside the 200 range.
+type APIError struct {
+	StatusCode int
+	// RateLimit holds the x-ratelimit-limit header when the request was rate limited.
+	RateLimit string
+}
+
+func (e *APIError) Error() string {
+	if e.RateLimited() {
+		return fmt.Sprintf("rate limited - https://developer.hashicorp.com/terraform/cloud-docs/api-docs#rate-limiting, limit: %s", e.RateLimit)
+	}
+	return fmt.Sprintf("unexpected status code: %d", e.StatusCode)
+}
+
+// RateLimited reports whether the request was rejected because of rate limiting.
+func (e *APIError) RateLimited() bool {
+	return e.StatusCode == http.StatusTooManyRequests
+}
+
+// buildRegistryModuleUrl Builds the URL for a single module in the Terraform Cloud Private Registry API.
+func buildRegistryModuleUrl(orgName, namespace, name, provider string) (*url.URL, error) {
+	baseURL, err := url.Parse(fmt.Sprintf("https://app.terraform.io/api/v2/organizations/%s/registry-modules/private/%s/%s/%s",
+		orgName, url.PathEscape(namespace), url.PathEscape(name), url.PathEscape(provider)))
+	if err != nil {
+		return nil, err
+	}
+	return baseURL, nil
+}
+
+// buildRegistryProvidersUrl Builds the URL for the providers in the Terraform Cloud Private Registry API. Any path
+// elements are escaped and appended to the base URL.
+func buildRegistryProvidersUrl(orgName string, elem ...string) (*url.URL, error) {
+	baseURL, err := url.Parse(fmt.Sprintf("https://app.terraform.io/api/v2/organizations/%s/registry-providers", orgName))
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range elem {
+		baseURL = baseURL.JoinPath(e)
+	}
+	return baseURL, nil
+}
+
+// buildModuleRegistryProtocolUrl Builds the URL for a module version in the Terraform Cloud implementation of the module
+// registry protocol, which serves the inputs, outputs and resources of each published version.
+func buildModuleRegistryProtocolUrl(namespace, name, provider, version string) (*url.URL, error) {
+	baseURL, err := url.Parse(fmt.Sprintf("https://app.terraform.io/api/registry/v1/modules/%s/%s/%s/%s",
+		url.PathEscape(namespace), url.PathEscape(name), url.PathEscape(provider), url.PathEscape(version)))
+	if err != nil {
+		return nil, err
+	}
+	return baseURL, nil
+}
+
+// getJSON sends an authenticated GET request to the given URL and decodes the JSON response body into v. It is used for
+// endpoints that return a single object rather than a paginated list.
+func (c *Cartographer) getJSON(u *url.URL, v interface{}) error {
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.token)
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if err := checkStatusCode(res); err != nil {
+		return err
+	}
+
+	return json.NewDecoder(res.Body).Decode(v)
+}
+
+// checkStatusCode checks the status code of the response. If the status code is not in the 200 range, it returns an
+// *APIError describing the failure. A 429 status code is reported along with the rate limit returned by the API.
 func checkStatusCode(res *http.Response) error {
-	if res.StatusCode == 429 {
-		limit := res.Header.Get("x-ratelimit-limit")
-		return fmt.Errorf("rate limited - https://developer.hashicorp.com/terraform/cloud-docs/api-docs#rate-limiting, limit: %s", limit)
+	if res.StatusCode == http.StatusTooManyRequests {
+		return &APIError{StatusCode: res.StatusCode, RateLimit: res.Header.Get("x-ratelimit-limit")}
 	}
 
 	if res.StatusCode < 200 || res.StatusCode >= 300 {
-		return fmt.Errorf("unexpected status code: %d", res.StatusCode)
+		return &APIError{StatusCode: res.StatusCode}
 	}
 	return nil
 }
 
+// splitList splits a comma separated list returned by the Explorer API, such as the workspaces using a module, into
+// its trimmed, non-empty elements.
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 // preventRateLimiting prevents rate limiting by sleeping for a duration based on the rate limit and buffer.
 // TF Cloud has a rate limit of 30 requests per second. This function takes the inverse of the rate limit and multiplies
 // it by 1000 to get the duration to sleep. It then adds a buffer to the duration to prevent rate limiting.
@@ -100,3 +196,48 @@ func preventRateLimiting(pageCount int) {
 	delay := time.Duration(1000/rateLimit)*time.Millisecond + rateBuffer
 	time.Sleep(delay)
 }
+
+// QueryMeta holds the JSON:API links and pagination metadata returned for a query. The links are taken from the first
+// page of results, so Self can be used to re-run the query.
+type QueryMeta struct {
+	Self         string `json:"self"`
+	First        string `json:"first"`
+	Last         string `json:"last"`
+	PageSize     int    `json:"page-size"`
+	TotalPages   int    `json:"total-pages"`
+	TotalCount   int    `json:"total-count"`
+	PagesFetched int    `json:"pages-fetched"`
+}
+
+// apiLinks is the links object included in every paginated Terraform Cloud API response.
+type apiLinks struct {
+	Self  string      `json:"self"`
+	First string      `json:"first"`
+	Last  string      `json:"last"`
+	Prev  interface{} `json:"prev"`
+	Next  interface{} `json:"next"`
+}
+
+// apiMeta is the meta object included in every paginated Terraform Cloud API response.
+type apiMeta struct {
+	Pagination struct {
+		CurrentPage int         `json:"current-page"`
+		PageSize    int         `json:"page-size"`
+		NextPage    interface{} `json:"next-page"`
+		PrevPage    interface{} `json:"prev-page"`
+		TotalPages  int         `json:"total-pages"`
+		TotalCount  int         `json:"total-count"`
+	} `json:"pagination"`
+}
+
+// newQueryMeta builds a QueryMeta from the links and meta objects of the first page of a response.
+func newQueryMeta(links apiLinks, meta apiMeta) *QueryMeta {
+	return &QueryMeta{
+		Self:       links.Self,
+		First:      links.First,
+		Last:       links.Last,
+		PageSize:   meta.Pagination.PageSize,
+		TotalPages: meta.Pagination.TotalPages,
+		TotalCount: meta.Pagination.TotalCount,
+	}
+}
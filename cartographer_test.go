@@ -1,6 +1,7 @@
 package cartographer
 
 import (
+	"errors"
 	"net/http"
 	"testing"
 )
@@ -34,7 +35,7 @@ func TestBuildUrl(t *testing.T) {
 	orgName := "testOrg"
 	expectedUrl := "https://app.terraform.io/api/v2/organizations/testOrg/explorer"
 
-	url, err := buildUrl(orgName)
+	url, err := buildExplorerUrl(orgName)
 
 	if err != nil {
 		t.Errorf("Expected no error, but got %v", err)
@@ -55,4 +56,16 @@ func TestCheckStatusCode(t *testing.T) {
 	if err != nil {
 		t.Errorf("Expected no error, but got %v", err)
 	}
+
+	res = &http.Response{
+		StatusCode: 429,
+		Header:     http.Header{"X-Ratelimit-Limit": []string{"30"}},
+	}
+
+	err = checkStatusCode(res)
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || !apiErr.RateLimited() {
+		t.Errorf("Expected a rate limited *APIError, but got %v", err)
+	}
 }
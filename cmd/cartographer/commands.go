@@ -0,0 +1,189 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/majesticbeast/cartographer"
+	"github.com/majesticbeast/cartographer/format"
+)
+
+// command is a subcommand of the CLI.
+type command struct {
+	name    string
+	summary string
+	// filters reports whether the command accepts -filter flags.
+	filters bool
+	// run queries the organization and returns the selected fields of the results, or the default fields if none are
+	// selected.
+	run func(c *cartographer.Cartographer, filters []filterSpec, registry *cartographer.RegistryModuleOptions, fields []string) (*format.Table, error)
+}
+
+var commands = map[string]command{
+	"workspaces": {
+		name:    "workspaces",
+		summary: "list workspaces",
+		filters: true,
+		run: func(c *cartographer.Cartographer, specs []filterSpec, _ *cartographer.RegistryModuleOptions, fields []string) (*format.Table, error) {
+			filters, err := buildFilters(specs, cartographer.WorkspaceUpdatedAt, func(t cartographer.WorkspaceFilterType, op cartographer.FilterOperator, v string) cartographer.WorkspaceFilter {
+				return cartographer.WorkspaceFilter{Type: t, Operator: op, Value: v}
+			})
+			if err != nil {
+				return nil, err
+			}
+			workspaces, err := c.Workspaces(filters)
+			if err != nil {
+				return nil, err
+			}
+			return format.Workspaces(workspaces, fields...)
+		},
+	},
+	"modules": {
+		name:    "modules",
+		summary: "list modules in use",
+		filters: true,
+		run: func(c *cartographer.Cartographer, specs []filterSpec, _ *cartographer.RegistryModuleOptions, fields []string) (*format.Table, error) {
+			filters, err := buildFilters(specs, cartographer.ModuleInWorkspaces, func(t cartographer.ModuleFilterType, op cartographer.FilterOperator, v string) cartographer.ModuleFilter {
+				return cartographer.ModuleFilter{Type: t, Operator: op, Value: v}
+			})
+			if err != nil {
+				return nil, err
+			}
+			modules, err := c.Modules(filters)
+			if err != nil {
+				return nil, err
+			}
+			return format.Modules(modules, fields...)
+		},
+	},
+	"providers": {
+		name:    "providers",
+		summary: "list providers in use",
+		filters: true,
+		run: func(c *cartographer.Cartographer, specs []filterSpec, _ *cartographer.RegistryModuleOptions, fields []string) (*format.Table, error) {
+			filters, err := buildFilters(specs, cartographer.ProviderWorkspaces, func(t cartographer.ProviderFilterType, op cartographer.FilterOperator, v string) cartographer.ProviderFilter {
+				return cartographer.ProviderFilter{Type: t, Operator: op, Value: v}
+			})
+			if err != nil {
+				return nil, err
+			}
+			providers, err := c.Providers(filters)
+			if err != nil {
+				return nil, err
+			}
+			return format.Providers(providers, fields...)
+		},
+	},
+	"tf-versions": {
+		name:    "tf-versions",
+		summary: "list Terraform versions in use",
+		filters: true,
+		run: func(c *cartographer.Cartographer, specs []filterSpec, _ *cartographer.RegistryModuleOptions, fields []string) (*format.Table, error) {
+			filters, err := buildFilters(specs, cartographer.TFVersionWorkspaces, func(t cartographer.TFVersionFilterType, op cartographer.FilterOperator, v string) cartographer.TFVersionFilter {
+				return cartographer.TFVersionFilter{Type: t, Operator: op, Value: v}
+			})
+			if err != nil {
+				return nil, err
+			}
+			versions, err := c.TFVersions(filters)
+			if err != nil {
+				return nil, err
+			}
+			return format.TFVersions(versions, fields...)
+		},
+	},
+	"registry": {
+		name:    "registry",
+		summary: "list private registry modules",
+		run: func(c *cartographer.Cartographer, _ []filterSpec, registry *cartographer.RegistryModuleOptions, fields []string) (*format.Table, error) {
+			modules, err := c.SearchPrivateRegistryModules(*registry)
+			if err != nil {
+				return nil, err
+			}
+			return format.RegistryModules(modules, fields...)
+		},
+	},
+}
+
+// registryFlags registers the search flags of the registry command and returns the options they set, or nil for
+// other commands.
+func registryFlags(fs *flag.FlagSet, cmd command) *cartographer.RegistryModuleOptions {
+	if cmd.name != "registry" {
+		return nil
+	}
+
+	opts := &cartographer.RegistryModuleOptions{}
+	fs.StringVar(&opts.Search, "search", "", "search modules by name or namespace")
+	fs.StringVar(&opts.Provider, "provider", "", "only list modules for this provider")
+	fs.StringVar(&opts.Namespace, "namespace", "", "only list modules in this namespace")
+	fs.StringVar(&opts.OrganizationName, "organization-name", "", "only list modules published by this organization")
+	fs.Func("registry", "only list modules in this registry: private or public", func(s string) error {
+		switch s {
+		case "private":
+			opts.Registry = cartographer.PrivateRegistry
+		case "public":
+			opts.Registry = cartographer.PublicRegistry
+		default:
+			return fmt.Errorf("unknown registry %q", s)
+		}
+		return nil
+	})
+	return opts
+}
+
+// filterSpec is a filter parsed from a -filter flag.
+type filterSpec struct {
+	field    string
+	operator cartographer.FilterOperator
+	value    string
+}
+
+// parseFilterSpecs parses filters of the form field:operator:value. The value may itself contain colons.
+func parseFilterSpecs(flags []string) ([]filterSpec, error) {
+	operators := enumByName(cartographer.IsAfter)
+
+	var specs []filterSpec
+	for _, f := range flags {
+		parts := strings.SplitN(f, ":", 3)
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("invalid filter %q, expected field:operator:value", f)
+		}
+		op, ok := operators[parts[1]]
+		if !ok {
+			return nil, fmt.Errorf("invalid filter %q, unknown operator %q", f, parts[1])
+		}
+		specs = append(specs, filterSpec{field: parts[0], operator: op, value: parts[2]})
+	}
+	return specs, nil
+}
+
+// buildFilters converts filter specs into a query's filters, where last is the last value of the query's filter type.
+func buildFilters[F any, T interface {
+	~int
+	String() string
+}](specs []filterSpec, last T, build func(T, cartographer.FilterOperator, string) F) ([]F, error) {
+	types := enumByName(last)
+
+	var filters []F
+	for _, spec := range specs {
+		t, ok := types[spec.field]
+		if !ok {
+			return nil, fmt.Errorf("unknown filter field %q", spec.field)
+		}
+		filters = append(filters, build(t, spec.operator, spec.value))
+	}
+	return filters, nil
+}
+
+// enumByName maps the string form of every value of an enum, from zero up to last, to the value.
+func enumByName[T interface {
+	~int
+	String() string
+}](last T) map[string]T {
+	values := make(map[string]T)
+	for t := T(0); t <= last; t++ {
+		values[t.String()] = t
+	}
+	return values
+}
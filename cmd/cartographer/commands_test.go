@@ -0,0 +1,34 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/majesticbeast/cartographer"
+)
+
+func TestBuildFilters(t *testing.T) {
+	specs, err := parseFilterSpecs([]string{"workspace-name:is:a:b", "drifted:is:true"})
+	if err != nil {
+		t.Fatalf("parseFilterSpecs() returned an error: %v", err)
+	}
+
+	filters, err := buildFilters(specs, cartographer.WorkspaceUpdatedAt, func(t cartographer.WorkspaceFilterType, op cartographer.FilterOperator, v string) cartographer.WorkspaceFilter {
+		return cartographer.WorkspaceFilter{Type: t, Operator: op, Value: v}
+	})
+	if err != nil {
+		t.Fatalf("buildFilters() returned an error: %v", err)
+	}
+
+	expected := []cartographer.WorkspaceFilter{
+		{Type: cartographer.WorkspaceName, Operator: cartographer.Is, Value: "a:b"},
+		{Type: cartographer.WorkspaceDrifted, Operator: cartographer.Is, Value: "true"},
+	}
+	if !reflect.DeepEqual(filters, expected) {
+		t.Errorf("buildFilters() returned %+v, expected %+v", filters, expected)
+	}
+
+	if _, err := parseFilterSpecs([]string{"name-only"}); err == nil {
+		t.Errorf("parseFilterSpecs() with a malformed filter expected an error")
+	}
+}
@@ -0,0 +1,13 @@
+package main
+
+import (
+	"github.com/majesticbeast/cartographer"
+)
+
+// findToken returns the API token from TFE_TOKEN, or else the token Terraform CLI would use for Terraform Cloud.
+func findToken(getenv func(string) string) (string, error) {
+	if token := getenv("TFE_TOKEN"); token != "" {
+		return token, nil
+	}
+	return cartographer.FindToken(cartographer.DefaultHostname)
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/majesticbeast/cartographer"
+)
+
+func TestFindToken(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("TF_CLI_CONFIG_FILE", "")
+	t.Setenv("TF_TOKEN_app_terraform_io", "")
+	env := map[string]string{}
+
+	_, err := findToken(testEnv(env))
+	var notFound *cartographer.NoCredentialsError
+	if !errors.As(err, &notFound) {
+		t.Errorf("findToken() without credentials returned %v, expected a NoCredentialsError", err)
+	}
+
+	t.Setenv("TF_TOKEN_app_terraform_io", "from-terraform-env")
+	if token, err := findToken(testEnv(env)); err != nil || token != "from-terraform-env" {
+		t.Errorf("findToken() returned %q, %v, expected the Terraform token", token, err)
+	}
+
+	env["TFE_TOKEN"] = "from-env"
+	if token, err := findToken(testEnv(env)); err != nil || token != "from-env" {
+		t.Errorf("findToken() returned %q, %v, expected TFE_TOKEN to take precedence", token, err)
+	}
+}
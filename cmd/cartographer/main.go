@@ -0,0 +1,155 @@
+// Command cartographer queries the Terraform Cloud Explorer API and private registry from the command line.
+//
+// Usage:
+//
+//	cartographer <command> [flags]
+//
+// The organization is read from -org or TFE_ORGANIZATION, and the API token from TFE_TOKEN or wherever Terraform CLI
+// would find it. Run "cartographer <command> -h" for the flags of a command.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/majesticbeast/cartographer"
+	"github.com/majesticbeast/cartographer/format"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr, os.Getenv, nil))
+}
+
+// options are the flags shared by every command.
+type options struct {
+	org     string
+	filters filterFlag
+	sort    string
+	fields  string
+	output  format.Format
+}
+
+// filterFlag collects repeated -filter flags.
+type filterFlag []string
+
+func (f *filterFlag) String() string {
+	return strings.Join(*f, ", ")
+}
+
+func (f *filterFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+// run runs the command line args and returns the process exit code. Requests are sent through client, or a default
+// HTTP client when it is nil.
+func run(args []string, stdout, stderr io.Writer, getenv func(string) string, client cartographer.Doer) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "-help" || args[0] == "help" {
+		usage(stderr)
+		if len(args) == 0 {
+			return 2
+		}
+		return 0
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "cartographer: unknown command %q\n\n", args[0])
+		usage(stderr)
+		return 2
+	}
+
+	fs := flag.NewFlagSet("cartographer "+cmd.name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	var opts options
+	fs.StringVar(&opts.org, "org", getenv("TFE_ORGANIZATION"), "Terraform Cloud organization name (default $TFE_ORGANIZATION)")
+	fs.StringVar(&opts.sort, "sort", "", "output field to sort by, prefixed with - for descending order")
+	fs.StringVar(&opts.fields, "fields", "", "comma separated fields to output (default depends on the command)")
+	fs.Func("output", "output format: table, jsonl, csv or markdown (default table)", func(s string) error {
+		f, err := format.ParseFormat(s)
+		opts.output = f
+		return err
+	})
+	if cmd.filters {
+		fs.Var(&opts.filters, "filter", "filter as field:operator:value, may be repeated (e.g. name:contains:iam)")
+	}
+	registry := registryFlags(fs, cmd)
+
+	if err := fs.Parse(args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(stderr, "cartographer %s: unexpected arguments %v\n", cmd.name, fs.Args())
+		return 2
+	}
+
+	if err := execute(cmd, opts, registry, stdout, getenv, client); err != nil {
+		fmt.Fprintf(stderr, "cartographer %s: %v\n", cmd.name, err)
+		return 1
+	}
+	return 0
+}
+
+// execute queries the organization and writes the results.
+func execute(cmd command, opts options, registry *cartographer.RegistryModuleOptions, stdout io.Writer,
+	getenv func(string) string, client cartographer.Doer) error {
+	if opts.org == "" {
+		return errors.New("no organization given, set -org or TFE_ORGANIZATION")
+	}
+	token, err := findToken(getenv)
+	if err != nil {
+		return err
+	}
+
+	var c *cartographer.Cartographer
+	if client == nil {
+		c = cartographer.NewCartographer(opts.org, token)
+	} else {
+		c = cartographer.NewCartographerWithClient(opts.org, token, client)
+	}
+
+	filters, err := parseFilterSpecs(opts.filters)
+	if err != nil {
+		return err
+	}
+
+	var fields []string
+	if opts.fields != "" {
+		fields = strings.Split(opts.fields, ",")
+	}
+	table, err := cmd.run(c, filters, registry, fields)
+	if err != nil {
+		return err
+	}
+
+	if opts.sort != "" {
+		if err := table.SortBy(strings.TrimPrefix(opts.sort, "-"), strings.HasPrefix(opts.sort, "-")); err != nil {
+			return err
+		}
+	}
+	return table.Write(stdout, opts.output)
+}
+
+// usage writes the list of commands.
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: cartographer <command> [flags]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+
+	var names []string
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-12s %s\n", name, commands[name].summary)
+	}
+}
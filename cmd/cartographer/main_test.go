@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type mockDoer struct {
+	requests []*http.Request
+	body     string
+}
+
+func (m *mockDoer) Do(req *http.Request) (*http.Response, error) {
+	m.requests = append(m.requests, req)
+	return &http.Response{
+		StatusCode: 200,
+		Body:       io.NopCloser(strings.NewReader(m.body)),
+	}, nil
+}
+
+const modulesBody = `{"data": [
+	{"attributes": {"name": "vpc", "source": "app.terraform.io/test/vpc/aws", "version": "1.0.0", "workspace-count": 2}, "id": "1", "type": "t"},
+	{"attributes": {"name": "iam", "source": "app.terraform.io/test/iam/aws", "version": "2.1.0", "workspace-count": 10}, "id": "2", "type": "t"}
+],
+"links": {"self": "test", "first": "test", "last": "test", "prev": null, "next": null},
+"meta": {"pagination": {"current-page": 1, "page-size": 100, "next-page": null, "prev-page": null, "total-pages": 1, "total-count": 2}}}`
+
+func testEnv(env map[string]string) func(string) string {
+	return func(key string) string { return env[key] }
+}
+
+func TestRun(t *testing.T) {
+	doer := &mockDoer{body: modulesBody}
+	var stdout, stderr bytes.Buffer
+	env := testEnv(map[string]string{"TFE_ORGANIZATION": "test", "TFE_TOKEN": "secret"})
+
+	args := []string{"modules", "-filter", "name:contains:a", "-sort", "-workspace-count", "-fields", "name,workspace-count", "-output", "csv"}
+	if code := run(args, &stdout, &stderr, env, doer); code != 0 {
+		t.Fatalf("run() returned %d: %s", code, stderr.String())
+	}
+
+	expected := "name,workspace-count\niam,10\nvpc,2\n"
+	if stdout.String() != expected {
+		t.Errorf("run() wrote:\n%s\nexpected:\n%s", stdout.String(), expected)
+	}
+
+	req := doer.requests[0]
+	if got := req.URL.Query().Get("filter[0][name][contains][0]"); got != "a" {
+		t.Errorf("run() sent filter value %q, expected a", got)
+	}
+	if got := req.Header.Get("Authorization"); got != "Bearer secret" {
+		t.Errorf("run() sent Authorization %q", got)
+	}
+	if !strings.Contains(req.URL.Path, "/organizations/test/") {
+		t.Errorf("run() queried %s, expected the test organization", req.URL.Path)
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	env := testEnv(map[string]string{"TFE_TOKEN": "secret"})
+
+	tests := []struct {
+		args     []string
+		code     int
+		contains string
+	}{
+		{nil, 2, "Usage: cartographer"},
+		{[]string{"bogus"}, 2, `unknown command "bogus"`},
+		{[]string{"modules"}, 1, "no organization given"},
+		{[]string{"modules", "-org", "test", "-output", "yaml"}, 2, `unknown format "yaml"`},
+		{[]string{"modules", "-org", "test", "-filter", "name:like:a"}, 1, `unknown operator "like"`},
+		{[]string{"modules", "-org", "test", "-filter", "colour:is:a"}, 1, `unknown filter field "colour"`},
+		{[]string{"modules", "-org", "test", "-fields", "colour"}, 1, `unknown column "colour"`},
+		{[]string{"modules", "-org", "test", "-sort", "colour"}, 1, `unknown sort column "colour"`},
+		{[]string{"registry", "-org", "test", "-filter", "name:is:a"}, 2, "flag provided but not defined: -filter"},
+	}
+
+	for _, test := range tests {
+		var stdout, stderr bytes.Buffer
+		code := run(test.args, &stdout, &stderr, env, &mockDoer{body: modulesBody})
+		if code != test.code || !strings.Contains(stderr.String(), test.contains) {
+			t.Errorf("run(%v) returned %d with %q, expected %d containing %q", test.args, code, stderr.String(), test.code, test.contains)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package cartographer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+)
+
+// DefaultHostname is the hostname of Terraform Cloud, which credentials are looked up for by default.
+const DefaultHostname = "app.terraform.io"
+
+// credentialsBlockPattern matches a credentials block in an HCL CLI config file and captures its hostname and body.
+var credentialsBlockPattern = regexp.MustCompile(`(?m)^\s*credentials\s+"([^"]+)"\s*\{([^}]*)\}`)
+
+// tokenAttributePattern matches the token attribute in the body of a credentials block.
+var tokenAttributePattern = regexp.MustCompile(`(?m)^\s*token\s*=\s*"([^"]*)"`)
+
+// NoCredentialsError is returned when no API token can be found for a hostname. Searched lists the places that were
+// checked, in order.
+type NoCredentialsError struct {
+	Hostname string
+	Searched []string
+}
+
+func (e *NoCredentialsError) Error() string {
+	return fmt.Sprintf("no API token found for %s, checked %s; run terraform login or set %s",
+		e.Hostname, strings.Join(e.Searched, ", "), TokenEnvVar(e.Hostname))
+}
+
+// TokenEnvVar returns the name of the environment variable Terraform reads the token for hostname from, such as
+// TF_TOKEN_app_terraform_io. Dots are replaced by underscores and hyphens by double underscores.
+func TokenEnvVar(hostname string) string {
+	return "TF_TOKEN_" + strings.NewReplacer(".", "_", "-", "__").Replace(hostname)
+}
+
+// FindToken looks up the API token for hostname with the same precedence as Terraform CLI: the TF_TOKEN_<host>
+// environment variable, then credentials blocks in the CLI config file (TF_CLI_CONFIG_FILE or ~/.terraformrc), then
+// the credentials.tfrc.json file written by terraform login. It returns a *NoCredentialsError if none of them has a
+// token, and an error naming the file if one of them cannot be parsed.
+func FindToken(hostname string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		home = ""
+	}
+	return findToken(hostname, os.Getenv, home)
+}
+
+// NewCartographerFromCredentials Creates a new Cartographer client for the given organization using the token FindToken
+// discovers for Terraform Cloud.
+func NewCartographerFromCredentials(orgName string) (*Cartographer, error) {
+	token, err := FindToken(DefaultHostname)
+	if err != nil {
+		return nil, err
+	}
+	return NewCartographer(orgName, token), nil
+}
+
+// findToken implements FindToken with the environment and home directory passed in.
+func findToken(hostname string, getenv func(string) string, home string) (string, error) {
+	hostname = strings.ToLower(hostname)
+	notFound := &NoCredentialsError{Hostname: hostname}
+
+	envVar := TokenEnvVar(hostname)
+	if token := getenv(envVar); token != "" {
+		return token, nil
+	}
+	notFound.Searched = append(notFound.Searched, envVar)
+
+	configPath := getenv("TF_CLI_CONFIG_FILE")
+	if configPath == "" {
+		configPath = cliConfigPath(getenv, home)
+	}
+	if configPath != "" {
+		token, err := tokenFromCLIConfig(configPath, hostname)
+		if err != nil {
+			return "", err
+		}
+		if token != "" {
+			return token, nil
+		}
+		notFound.Searched = append(notFound.Searched, configPath)
+	}
+
+	if dir := cliConfigDir(getenv, home); dir != "" {
+		credentialsPath := filepath.Join(dir, "credentials.tfrc.json")
+		token, err := tokenFromCLIConfig(credentialsPath, hostname)
+		if err != nil {
+			return "", err
+		}
+		if token != "" {
+			return token, nil
+		}
+		notFound.Searched = append(notFound.Searched, credentialsPath)
+	}
+
+	return "", notFound
+}
+
+// cliConfigPath returns the default location of the CLI config file, or an empty string if it cannot be determined.
+func cliConfigPath(getenv func(string) string, home string) string {
+	if runtime.GOOS == "windows" {
+		if appData := getenv("APPDATA"); appData != "" {
+			return filepath.Join(appData, "terraform.rc")
+		}
+		return ""
+	}
+	if home == "" {
+		return ""
+	}
+	return filepath.Join(home, ".terraformrc")
+}
+
+// cliConfigDir returns the directory terraform login writes credentials.tfrc.json to, or an empty string if it cannot
+// be determined.
+func cliConfigDir(getenv func(string) string, home string) string {
+	if runtime.GOOS == "windows" {
+		if appData := getenv("APPDATA"); appData != "" {
+			return filepath.Join(appData, "terraform.d")
+		}
+		return ""
+	}
+	if home == "" {
+		return ""
+	}
+	return filepath.Join(home, ".terraform.d")
+}
+
+// tokenFromCLIConfig returns the token for hostname from the credentials of a CLI config file, or an empty string if
+// the file does not exist or has no credentials for the host. Files ending in .json are parsed as JSON and others as
+// HCL, of which only credentials blocks with a literal token are understood.
+func tokenFromCLIConfig(path, hostname string) (string, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if strings.HasSuffix(path, ".json") {
+		var config struct {
+			Credentials map[string]struct {
+				Token string `json:"token"`
+			} `json:"credentials"`
+		}
+		if err := json.Unmarshal(data, &config); err != nil {
+			return "", fmt.Errorf("parse %s: %w", path, err)
+		}
+		for host, credentials := range config.Credentials {
+			if strings.EqualFold(host, hostname) {
+				return credentials.Token, nil
+			}
+		}
+		return "", nil
+	}
+
+	for _, block := range credentialsBlockPattern.FindAllSubmatch(data, -1) {
+		if !strings.EqualFold(string(block[1]), hostname) {
+			continue
+		}
+		if token := tokenAttributePattern.FindSubmatch(block[2]); token != nil {
+			return string(token[1]), nil
+		}
+		return "", fmt.Errorf("parse %s: credentials block for %s has no token", path, hostname)
+	}
+	return "", nil
+}
@@ -0,0 +1,90 @@
+package cartographer
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTokenEnvVar(t *testing.T) {
+	for hostname, expected := range map[string]string{
+		"app.terraform.io":      "TF_TOKEN_app_terraform_io",
+		"tfe.my-company.com":    "TF_TOKEN_tfe_my__company_com",
+		"localhost":             "TF_TOKEN_localhost",
+		"eu.app.terraform.io":   "TF_TOKEN_eu_app_terraform_io",
+		"my-tfe.internal-1.net": "TF_TOKEN_my__tfe_internal__1_net",
+	} {
+		if got := TokenEnvVar(hostname); got != expected {
+			t.Errorf("TokenEnvVar(%q) returned %q, expected %q", hostname, got, expected)
+		}
+	}
+}
+
+func TestFindToken(t *testing.T) {
+	home := t.TempDir()
+	env := map[string]string{}
+	getenv := func(key string) string { return env[key] }
+
+	_, err := findToken(DefaultHostname, getenv, home)
+	var notFound *NoCredentialsError
+	if !errors.As(err, &notFound) {
+		t.Fatalf("findToken() without credentials returned %v, expected a NoCredentialsError", err)
+	}
+	if len(notFound.Searched) != 3 || !strings.Contains(err.Error(), "TF_TOKEN_app_terraform_io") {
+		t.Errorf("findToken() returned %q, expected all three places to be listed", err)
+	}
+
+	writeFile := func(path, content string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writeFile(filepath.Join(home, ".terraform.d", "credentials.tfrc.json"),
+		`{"credentials": {"App.Terraform.io": {"token": "from-credentials-file"}}}`)
+	if token, err := findToken(DefaultHostname, getenv, home); err != nil || token != "from-credentials-file" {
+		t.Errorf("findToken() returned %q, %v, expected the credentials file token", token, err)
+	}
+
+	writeFile(filepath.Join(home, ".terraformrc"), `
+plugin_cache_dir = "$HOME/.terraform.d/plugin-cache"
+
+credentials "tfe.example.com" {
+  token = "other-host"
+}
+
+credentials "app.terraform.io" {
+  token = "from-cli-config"
+}
+`)
+	if token, err := findToken(DefaultHostname, getenv, home); err != nil || token != "from-cli-config" {
+		t.Errorf("findToken() returned %q, %v, expected the CLI config token", token, err)
+	}
+	if token, err := findToken("tfe.example.com", getenv, home); err != nil || token != "other-host" {
+		t.Errorf("findToken() returned %q, %v, expected the token of the other host", token, err)
+	}
+
+	customConfig := filepath.Join(home, "custom.tfrc.json")
+	writeFile(customConfig, `{"credentials": {"app.terraform.io": {"token": "from-custom-config"}}}`)
+	env["TF_CLI_CONFIG_FILE"] = customConfig
+	if token, err := findToken(DefaultHostname, getenv, home); err != nil || token != "from-custom-config" {
+		t.Errorf("findToken() returned %q, %v, expected TF_CLI_CONFIG_FILE to replace ~/.terraformrc", token, err)
+	}
+
+	env["TF_TOKEN_app_terraform_io"] = "from-env"
+	if token, err := findToken(DefaultHostname, getenv, home); err != nil || token != "from-env" {
+		t.Errorf("findToken() returned %q, %v, expected the environment to take precedence", token, err)
+	}
+
+	delete(env, "TF_TOKEN_app_terraform_io")
+	writeFile(customConfig, `{"credentials": `)
+	if _, err := findToken(DefaultHostname, getenv, home); err == nil || !strings.Contains(err.Error(), customConfig) {
+		t.Errorf("findToken() with a malformed config returned %v, expected an error naming the file", err)
+	}
+}
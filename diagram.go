@@ -0,0 +1,253 @@
+package cartographer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	ColorNone DiagramColor = iota
+	ColorByDrift
+	ColorByChecks
+)
+
+// DiagramColor selects how workspace nodes are coloured in a rendered diagram.
+type DiagramColor int
+
+const (
+	healthyColor   = "#d4edda"
+	unhealthyColor = "#f8d7da"
+)
+
+// DiagramOptions controls which part of a Graph is rendered and how. Project and Module may be combined, in which case
+// only workspaces matching both are rendered.
+type DiagramOptions struct {
+	// Project limits the diagram to the workspaces in the project with this name.
+	Project string
+	// Module limits the diagram to the workspaces using the module with this source.
+	Module string
+	// ClusterByProject draws workspaces inside a box per project instead of linking them to project nodes.
+	ClusterByProject bool
+	// Color selects how workspace nodes are coloured.
+	Color DiagramColor
+}
+
+// diagram is the scoped set of nodes and edges to render along with the project each workspace is clustered in.
+type diagram struct {
+	nodes    []Node
+	edges    []Edge
+	clusters map[string][]Node
+	// unclustered are the nodes drawn outside any project cluster.
+	unclustered []Node
+}
+
+// newDiagram selects the workspaces matching the options along with everything they depend on.
+func (g *Graph) newDiagram(opts DiagramOptions) diagram {
+	included := make(map[string]bool)
+	for _, w := range g.Nodes(WorkspaceNode) {
+		if opts.Project != "" && g.workspaceProject(w.ID) != opts.Project {
+			continue
+		}
+		if opts.Module != "" && !g.usesModule(w.ID, opts.Module) {
+			continue
+		}
+		included[w.ID] = true
+		for _, e := range g.out[w.ID] {
+			included[e.To] = true
+		}
+	}
+
+	d := diagram{clusters: make(map[string][]Node)}
+	for _, n := range g.Nodes() {
+		if !included[n.ID] {
+			continue
+		}
+		if opts.ClusterByProject && n.Kind == ProjectNode {
+			continue
+		}
+		d.nodes = append(d.nodes, n)
+
+		if opts.ClusterByProject && n.Kind == WorkspaceNode {
+			if project := g.workspaceProject(n.ID); project != "" {
+				d.clusters[project] = append(d.clusters[project], n)
+				continue
+			}
+		}
+		d.unclustered = append(d.unclustered, n)
+	}
+
+	for _, e := range g.Edges() {
+		if !included[e.From] || !included[e.To] {
+			continue
+		}
+		if opts.ClusterByProject && e.Kind == InProject {
+			continue
+		}
+		d.edges = append(d.edges, e)
+	}
+
+	return d
+}
+
+// clusterNames returns the names of the project clusters in sorted order.
+func (d diagram) clusterNames() []string {
+	var names []string
+	for name := range d.clusters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// workspaceProject returns the name of the project the workspace belongs to, or an empty string.
+func (g *Graph) workspaceProject(id string) string {
+	for _, e := range g.out[id] {
+		if e.Kind == InProject {
+			return g.nodes[e.To].Label
+		}
+	}
+	return ""
+}
+
+// usesModule reports whether the workspace uses the module with the given source.
+func (g *Graph) usesModule(id, source string) bool {
+	moduleId := ModuleNodeID(source)
+	for _, e := range g.out[id] {
+		if e.Kind == UsesModule && e.To == moduleId {
+			return true
+		}
+	}
+	return false
+}
+
+// nodeColor returns the fill colour of a node for the given colouring, or an empty string if it is not coloured.
+func nodeColor(n Node, color DiagramColor) string {
+	if n.Kind != WorkspaceNode {
+		return ""
+	}
+
+	switch color {
+	case ColorByDrift:
+		if n.Attributes["drifted"] == "true" {
+			return unhealthyColor
+		}
+		return healthyColor
+	case ColorByChecks:
+		if failed := n.Attributes["checks-failed"]; failed != "" && failed != "0" {
+			return unhealthyColor
+		}
+		return healthyColor
+	}
+	return ""
+}
+
+// DOT renders the graph as a Graphviz DOT digraph.
+func (g *Graph) DOT(opts DiagramOptions) string {
+	d := g.newDiagram(opts)
+
+	var b strings.Builder
+	b.WriteString("digraph cartographer {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [style=filled, fillcolor=\"#ffffff\"];\n")
+
+	for i, name := range d.clusterNames() {
+		fmt.Fprintf(&b, "  subgraph cluster_%d {\n", i)
+		fmt.Fprintf(&b, "    label=%s;\n", dotQuote(name))
+		for _, n := range d.clusters[name] {
+			b.WriteString("    " + dotNode(n, opts.Color) + "\n")
+		}
+		b.WriteString("  }\n")
+	}
+
+	for _, n := range d.unclustered {
+		b.WriteString("  " + dotNode(n, opts.Color) + "\n")
+	}
+
+	for _, e := range d.edges {
+		attrs := fmt.Sprintf("label=%s", dotQuote(edgeLabel(e)))
+		fmt.Fprintf(&b, "  %s -> %s [%s];\n", dotQuote(e.From), dotQuote(e.To), attrs)
+	}
+
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// dotNode renders a node statement for a DOT graph.
+func dotNode(n Node, color DiagramColor) string {
+	shape := [...]string{"box", "folder", "component", "hexagon", "cylinder"}[n.Kind]
+	attrs := fmt.Sprintf("label=%s, shape=%s", dotQuote(n.Label), shape)
+	if fill := nodeColor(n, color); fill != "" {
+		attrs += fmt.Sprintf(", fillcolor=%s", dotQuote(fill))
+	}
+	return fmt.Sprintf("%s [%s];", dotQuote(n.ID), attrs)
+}
+
+// dotQuote quotes a string as a DOT identifier.
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
+
+// Mermaid renders the graph as a Mermaid flowchart.
+func (g *Graph) Mermaid(opts DiagramOptions) string {
+	d := g.newDiagram(opts)
+
+	ids := make(map[string]string)
+	for i, n := range d.nodes {
+		ids[n.ID] = fmt.Sprintf("n%d", i)
+	}
+
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	if opts.Color != ColorNone {
+		fmt.Fprintf(&b, "  classDef healthy fill:%s\n", healthyColor)
+		fmt.Fprintf(&b, "  classDef unhealthy fill:%s\n", unhealthyColor)
+	}
+
+	for i, name := range d.clusterNames() {
+		fmt.Fprintf(&b, "  subgraph c%d [%s]\n", i, mermaidQuote(name))
+		for _, n := range d.clusters[name] {
+			b.WriteString("    " + mermaidNode(ids[n.ID], n) + "\n")
+		}
+		b.WriteString("  end\n")
+	}
+
+	for _, n := range d.unclustered {
+		b.WriteString("  " + mermaidNode(ids[n.ID], n) + "\n")
+	}
+
+	for _, e := range d.edges {
+		fmt.Fprintf(&b, "  %s -->|%s| %s\n", ids[e.From], mermaidQuote(edgeLabel(e)), ids[e.To])
+	}
+
+	for _, n := range d.nodes {
+		switch nodeColor(n, opts.Color) {
+		case healthyColor:
+			fmt.Fprintf(&b, "  class %s healthy\n", ids[n.ID])
+		case unhealthyColor:
+			fmt.Fprintf(&b, "  class %s unhealthy\n", ids[n.ID])
+		}
+	}
+
+	return b.String()
+}
+
+// mermaidNode renders a node declaration for a Mermaid flowchart, using a shape per node kind.
+func mermaidNode(id string, n Node) string {
+	shapes := [...][2]string{{"[", "]"}, {"[/", "/]"}, {"[[", "]]"}, {"{{", "}}"}, {"[(", ")]"}}
+	shape := shapes[n.Kind]
+	return id + shape[0] + mermaidQuote(n.Label) + shape[1]
+}
+
+// mermaidQuote quotes a label for a Mermaid flowchart, escaping double quotes as an entity.
+func mermaidQuote(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, "#quot;") + `"`
+}
+
+// edgeLabel returns the label drawn on an edge: its kind and, when set, the version used.
+func edgeLabel(e Edge) string {
+	if e.Version == "" {
+		return e.Kind.String()
+	}
+	return e.Kind.String() + " " + e.Version
+}
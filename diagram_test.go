@@ -0,0 +1,71 @@
+package cartographer
+
+import (
+	"strings"
+	"testing"
+)
+
+func diagramGraph() *Graph {
+	workspaces := []Workspace{
+		{WorkspaceName: "network-prod", ProjectName: "network", ProjectExternalId: "prj-1", Drifted: true},
+		{WorkspaceName: "app", ProjectName: "apps", ProjectExternalId: "prj-2", ChecksFailed: 1},
+	}
+	modules := []Module{
+		{Name: "vpc", Source: "app.terraform.io/myOrgName/vpc/aws", Version: "1.0.0", Workspaces: "network-prod"},
+	}
+	providers := []Provider{
+		{Name: "aws", Source: "hashicorp/aws", Version: "5.0.0", Workspaces: "network-prod,app"},
+	}
+
+	return NewGraph(workspaces, modules, providers)
+}
+
+func TestGraphDOT(t *testing.T) {
+	g := diagramGraph()
+
+	expected := `digraph cartographer {
+  rankdir=LR;
+  node [style=filled, fillcolor="#ffffff"];
+  "module:app.terraform.io/myorgname/vpc/aws" [label="vpc", shape=component];
+  "project:prj-1" [label="network", shape=folder];
+  "provider:hashicorp/aws" [label="aws", shape=hexagon];
+  "workspace:network-prod" [label="network-prod", shape=box, fillcolor="#f8d7da"];
+  "workspace:network-prod" -> "module:app.terraform.io/myorgname/vpc/aws" [label="uses-module 1.0.0"];
+  "workspace:network-prod" -> "project:prj-1" [label="in-project"];
+  "workspace:network-prod" -> "provider:hashicorp/aws" [label="uses-provider 5.0.0"];
+}
+`
+	got := g.DOT(DiagramOptions{Module: "app.terraform.io/myOrgName/vpc/aws", Color: ColorByDrift})
+	if got != expected {
+		t.Errorf("DOT() returned:\n%s\nexpected:\n%s", got, expected)
+	}
+
+	clustered := g.DOT(DiagramOptions{ClusterByProject: true, Color: ColorByChecks})
+	if !strings.Contains(clustered, "subgraph cluster_0 {\n    label=\"apps\";\n    \"workspace:app\" [label=\"app\", shape=box, fillcolor=\"#f8d7da\"];\n  }") {
+		t.Errorf("DOT() with clustering returned:\n%s", clustered)
+	}
+	if strings.Contains(clustered, "in-project") {
+		t.Errorf("DOT() with clustering still contains project edges:\n%s", clustered)
+	}
+}
+
+func TestGraphMermaid(t *testing.T) {
+	g := diagramGraph()
+
+	got := g.Mermaid(DiagramOptions{Project: "apps", ClusterByProject: true, Color: ColorByDrift})
+	for _, line := range []string{
+		"flowchart LR",
+		"  subgraph c0 [\"apps\"]",
+		"    n1[\"app\"]",
+		"  n0{{\"aws\"}}",
+		"  n1 -->|\"uses-provider 5.0.0\"| n0",
+		"  class n1 healthy",
+	} {
+		if !strings.Contains(got, line+"\n") {
+			t.Errorf("Mermaid() output is missing %q:\n%s", line, got)
+		}
+	}
+	if strings.Contains(got, "network") {
+		t.Errorf("Mermaid() scoped to apps contains the network project:\n%s", got)
+	}
+}
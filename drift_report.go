@@ -0,0 +1,135 @@
+package cartographer
+
+import (
+	"sort"
+)
+
+// DriftReport retrieves every workspace in the organization and builds a DriftSummary from them. All workspaces are
+// needed, not just the drifted ones, so that each group can report the percentage of its workspaces that have drifted.
+func (c *Cartographer) DriftReport() (*DriftSummary, error) {
+	workspaces, err := c.Workspaces(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewDriftSummary(workspaces), nil
+}
+
+// NewDriftSummary aggregates drift across the given workspaces. Drifted workspaces and every group are sorted by the
+// number of drifted resources, highest first, with ties broken by name.
+func NewDriftSummary(workspaces []Workspace) *DriftSummary {
+	summary := &DriftSummary{}
+
+	projects := make(map[string]*DriftGroup)
+	repos := make(map[string]*DriftGroup)
+	versions := make(map[string]*DriftGroup)
+
+	for _, w := range workspaces {
+		summary.TotalWorkspaces++
+		summary.ResourcesDrifted += w.ResourcesDrifted
+		summary.ResourcesUndrifted += w.ResourcesUndrifted
+		if w.Drifted {
+			summary.DriftedWorkspaces++
+			summary.Workspaces = append(summary.Workspaces, w)
+		}
+
+		var repo string
+		if w.VcsRepoIdentifier != nil {
+			repo = *w.VcsRepoIdentifier
+		}
+
+		addToDriftGroup(projects, w.ProjectName, w)
+		addToDriftGroup(repos, repo, w)
+		addToDriftGroup(versions, w.WorkspaceTerraformVersion, w)
+	}
+
+	summary.DriftedPercent = percent(summary.DriftedWorkspaces, summary.TotalWorkspaces)
+	summary.ResourcesDriftedPercent = percent(summary.ResourcesDrifted, summary.ResourcesDrifted+summary.ResourcesUndrifted)
+
+	sort.SliceStable(summary.Workspaces, func(i, j int) bool {
+		a, b := summary.Workspaces[i], summary.Workspaces[j]
+		if a.ResourcesDrifted != b.ResourcesDrifted {
+			return a.ResourcesDrifted > b.ResourcesDrifted
+		}
+		return a.WorkspaceName < b.WorkspaceName
+	})
+
+	summary.ByProject = sortedDriftGroups(projects)
+	summary.ByVcsRepo = sortedDriftGroups(repos)
+	summary.ByTerraformVersion = sortedDriftGroups(versions)
+
+	return summary
+}
+
+// DriftSummary summarises drift across an organization's workspaces.
+type DriftSummary struct {
+	TotalWorkspaces         int     `json:"total-workspaces"`
+	DriftedWorkspaces       int     `json:"drifted-workspaces"`
+	DriftedPercent          float64 `json:"drifted-percent"`
+	ResourcesDrifted        int     `json:"resources-drifted"`
+	ResourcesUndrifted      int     `json:"resources-undrifted"`
+	ResourcesDriftedPercent float64 `json:"resources-drifted-percent"`
+	// Workspaces are the drifted workspaces.
+	Workspaces         []Workspace  `json:"workspaces"`
+	ByProject          []DriftGroup `json:"by-project"`
+	ByVcsRepo          []DriftGroup `json:"by-vcs-repo"`
+	ByTerraformVersion []DriftGroup `json:"by-terraform-version"`
+}
+
+// DriftGroup summarises drift across the workspaces sharing a project, VCS repository or Terraform version. Name is
+// empty for workspaces without a VCS repository.
+type DriftGroup struct {
+	Name                    string   `json:"name"`
+	Workspaces              int      `json:"workspaces"`
+	DriftedWorkspaces       int      `json:"drifted-workspaces"`
+	DriftedPercent          float64  `json:"drifted-percent"`
+	ResourcesDrifted        int      `json:"resources-drifted"`
+	ResourcesUndrifted      int      `json:"resources-undrifted"`
+	ResourcesDriftedPercent float64  `json:"resources-drifted-percent"`
+	DriftedWorkspaceNames   []string `json:"drifted-workspace-names"`
+}
+
+// addToDriftGroup adds the workspace to the group with the given name, creating the group if needed.
+func addToDriftGroup(groups map[string]*DriftGroup, name string, w Workspace) {
+	g, ok := groups[name]
+	if !ok {
+		g = &DriftGroup{Name: name}
+		groups[name] = g
+	}
+
+	g.Workspaces++
+	g.ResourcesDrifted += w.ResourcesDrifted
+	g.ResourcesUndrifted += w.ResourcesUndrifted
+	if w.Drifted {
+		g.DriftedWorkspaces++
+		g.DriftedWorkspaceNames = append(g.DriftedWorkspaceNames, w.WorkspaceName)
+	}
+}
+
+// sortedDriftGroups computes the percentages of each group and returns them sorted by drifted resources.
+func sortedDriftGroups(groups map[string]*DriftGroup) []DriftGroup {
+	var sorted []DriftGroup
+	for _, g := range groups {
+		g.DriftedPercent = percent(g.DriftedWorkspaces, g.Workspaces)
+		g.ResourcesDriftedPercent = percent(g.ResourcesDrifted, g.ResourcesDrifted+g.ResourcesUndrifted)
+		sort.Strings(g.DriftedWorkspaceNames)
+		sorted = append(sorted, *g)
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].ResourcesDrifted != sorted[j].ResourcesDrifted {
+			return sorted[i].ResourcesDrifted > sorted[j].ResourcesDrifted
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	return sorted
+}
+
+// percent returns part as a percentage of total, or zero when total is zero.
+func percent(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) / float64(total) * 100
+}
@@ -0,0 +1,44 @@
+package cartographer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewDriftSummary(t *testing.T) {
+	repo := "github.com/majesticbeast/infra"
+	workspaces := []Workspace{
+		{WorkspaceName: "a", ProjectName: "network", VcsRepoIdentifier: &repo, WorkspaceTerraformVersion: "1.5.0", Drifted: true, ResourcesDrifted: 2, ResourcesUndrifted: 8},
+		{WorkspaceName: "b", ProjectName: "network", VcsRepoIdentifier: &repo, WorkspaceTerraformVersion: "1.6.0", Drifted: false, ResourcesUndrifted: 10},
+		{WorkspaceName: "c", ProjectName: "compute", WorkspaceTerraformVersion: "1.6.0", Drifted: true, ResourcesDrifted: 5, ResourcesUndrifted: 5},
+		{WorkspaceName: "d", ProjectName: "compute", WorkspaceTerraformVersion: "1.6.0", Drifted: false},
+	}
+
+	summary := NewDriftSummary(workspaces)
+
+	if summary.TotalWorkspaces != 4 || summary.DriftedWorkspaces != 2 || summary.DriftedPercent != 50 {
+		t.Errorf("NewDriftSummary() returned %d/%d drifted (%v%%), expected 2/4 (50%%)", summary.DriftedWorkspaces, summary.TotalWorkspaces, summary.DriftedPercent)
+	}
+	if summary.ResourcesDrifted != 7 || summary.ResourcesUndrifted != 23 {
+		t.Errorf("NewDriftSummary() returned %d/%d drifted/undrifted resources, expected 7/23", summary.ResourcesDrifted, summary.ResourcesUndrifted)
+	}
+	if len(summary.Workspaces) != 2 || summary.Workspaces[0].WorkspaceName != "c" {
+		t.Errorf("NewDriftSummary() returned drifted workspaces %+v, expected c first", summary.Workspaces)
+	}
+
+	expectedProjects := []DriftGroup{
+		{Name: "compute", Workspaces: 2, DriftedWorkspaces: 1, DriftedPercent: 50, ResourcesDrifted: 5, ResourcesUndrifted: 5, ResourcesDriftedPercent: 50, DriftedWorkspaceNames: []string{"c"}},
+		{Name: "network", Workspaces: 2, DriftedWorkspaces: 1, DriftedPercent: 50, ResourcesDrifted: 2, ResourcesUndrifted: 18, ResourcesDriftedPercent: 10, DriftedWorkspaceNames: []string{"a"}},
+	}
+	if !reflect.DeepEqual(summary.ByProject, expectedProjects) {
+		t.Errorf("NewDriftSummary() returned projects %+v, expected %+v", summary.ByProject, expectedProjects)
+	}
+
+	if len(summary.ByVcsRepo) != 2 || summary.ByVcsRepo[0].Name != "" || summary.ByVcsRepo[1].Name != repo {
+		t.Errorf("NewDriftSummary() returned vcs repos %+v", summary.ByVcsRepo)
+	}
+
+	if len(summary.ByTerraformVersion) != 2 || summary.ByTerraformVersion[0].Name != "1.6.0" || summary.ByTerraformVersion[0].Workspaces != 3 {
+		t.Errorf("NewDriftSummary() returned terraform versions %+v", summary.ByTerraformVersion)
+	}
+}
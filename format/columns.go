@@ -0,0 +1,209 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/majesticbeast/cartographer"
+)
+
+// column is a named column of a result type and how to get its value from a result.
+type column[T any] struct {
+	name  string
+	value func(T) interface{}
+}
+
+// columnSet is every column of a result type in output order, and the columns used when none are selected.
+type columnSet[T any] struct {
+	all      []column[T]
+	defaults []string
+}
+
+// table builds a table of the selected columns of items, or of the default columns if none are selected.
+func (s columnSet[T]) table(items []T, selected []string) (*Table, error) {
+	if len(selected) == 0 {
+		selected = s.defaults
+	}
+
+	byName := make(map[string]column[T])
+	for _, c := range s.all {
+		byName[c.name] = c
+	}
+
+	columns := make([]column[T], len(selected))
+	for i, name := range selected {
+		c, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown column %q, expected one of %s", name, strings.Join(s.names(), ", "))
+		}
+		columns[i] = c
+	}
+
+	t := &Table{Columns: selected}
+	for _, item := range items {
+		row := make([]interface{}, len(columns))
+		for i, c := range columns {
+			row[i] = c.value(item)
+		}
+		t.rows = append(t.rows, row)
+	}
+	return t, nil
+}
+
+// names returns the names of every column in output order.
+func (s columnSet[T]) names() []string {
+	names := make([]string, len(s.all))
+	for i, c := range s.all {
+		names[i] = c.name
+	}
+	return names
+}
+
+var workspaceColumns = columnSet[cartographer.Workspace]{
+	all: []column[cartographer.Workspace]{
+		{"id", func(w cartographer.Workspace) interface{} { return w.Id }},
+		{"all-checks-succeeded", func(w cartographer.Workspace) interface{} { return w.AllChecksSucceeded }},
+		{"checks-errored", func(w cartographer.Workspace) interface{} { return w.ChecksErrored }},
+		{"checks-failed", func(w cartographer.Workspace) interface{} { return w.ChecksFailed }},
+		{"checks-passed", func(w cartographer.Workspace) interface{} { return w.ChecksPassed }},
+		{"checks-unknown", func(w cartographer.Workspace) interface{} { return w.ChecksUnknown }},
+		{"current-run-applied-at", func(w cartographer.Workspace) interface{} { return w.CurrentRunAppliedAt }},
+		{"current-run-external-id", func(w cartographer.Workspace) interface{} { return w.CurrentRunExternalId }},
+		{"current-run-status", func(w cartographer.Workspace) interface{} { return w.CurrentRunStatus }},
+		{"drifted", func(w cartographer.Workspace) interface{} { return w.Drifted }},
+		{"external-id", func(w cartographer.Workspace) interface{} { return w.ExternalId }},
+		{"module-count", func(w cartographer.Workspace) interface{} { return w.ModuleCount }},
+		{"modules", func(w cartographer.Workspace) interface{} { return workspaceModules(w.Modules) }},
+		{"organization-name", func(w cartographer.Workspace) interface{} { return w.OrganizationName }},
+		{"project-external-id", func(w cartographer.Workspace) interface{} { return w.ProjectExternalId }},
+		{"project-name", func(w cartographer.Workspace) interface{} { return w.ProjectName }},
+		{"provider-count", func(w cartographer.Workspace) interface{} { return w.ProviderCount }},
+		{"providers", func(w cartographer.Workspace) interface{} { return list(w.ProviderNames()) }},
+		{"resources-drifted", func(w cartographer.Workspace) interface{} { return w.ResourcesDrifted }},
+		{"resources-undrifted", func(w cartographer.Workspace) interface{} { return w.ResourcesUndrifted }},
+		{"state-version-terraform-version", func(w cartographer.Workspace) interface{} { return w.StateVersionTerraformVersion }},
+		{"vcs-repo-identifier", func(w cartographer.Workspace) interface{} { return w.VcsRepoIdentifier }},
+		{"workspace-created-at", func(w cartographer.Workspace) interface{} { return w.WorkspaceCreatedAt }},
+		{"workspace-name", func(w cartographer.Workspace) interface{} { return w.WorkspaceName }},
+		{"workspace-terraform-version", func(w cartographer.Workspace) interface{} { return w.WorkspaceTerraformVersion }},
+		{"workspace-updated-at", func(w cartographer.Workspace) interface{} { return w.WorkspaceUpdatedAt }},
+	},
+	defaults: []string{"workspace-name", "project-name", "workspace-terraform-version", "drifted", "checks-failed", "current-run-status"},
+}
+
+var moduleColumns = columnSet[cartographer.Module]{
+	all: []column[cartographer.Module]{
+		{"id", func(m cartographer.Module) interface{} { return m.Id }},
+		{"name", func(m cartographer.Module) interface{} { return m.Name }},
+		{"source", func(m cartographer.Module) interface{} { return m.Source }},
+		{"version", func(m cartographer.Module) interface{} { return m.Version }},
+		{"registry-type", func(m cartographer.Module) interface{} { return m.RegistryType }},
+		{"workspace-count", func(m cartographer.Module) interface{} { return m.WorkspaceCount }},
+		{"workspaces", func(m cartographer.Module) interface{} { return list(m.WorkspaceNames()) }},
+	},
+	defaults: []string{"name", "source", "version", "workspace-count"},
+}
+
+var providerColumns = columnSet[cartographer.Provider]{
+	all: []column[cartographer.Provider]{
+		{"id", func(p cartographer.Provider) interface{} { return p.Id }},
+		{"name", func(p cartographer.Provider) interface{} { return p.Name }},
+		{"source", func(p cartographer.Provider) interface{} { return p.Source }},
+		{"version", func(p cartographer.Provider) interface{} { return p.Version }},
+		{"registry-type", func(p cartographer.Provider) interface{} { return p.RegistryType }},
+		{"workspace-count", func(p cartographer.Provider) interface{} { return p.WorkspaceCount }},
+		{"workspaces", func(p cartographer.Provider) interface{} { return list(p.WorkspaceNames()) }},
+	},
+	defaults: []string{"name", "source", "version", "workspace-count"},
+}
+
+var tfVersionColumns = columnSet[cartographer.TFVersion]{
+	all: []column[cartographer.TFVersion]{
+		{"id", func(v cartographer.TFVersion) interface{} { return v.Id }},
+		{"version", func(v cartographer.TFVersion) interface{} { return v.Version }},
+		{"workspace-count", func(v cartographer.TFVersion) interface{} { return v.WorkspaceCount }},
+		{"workspaces", func(v cartographer.TFVersion) interface{} { return list(v.WorkspaceNames()) }},
+	},
+	defaults: []string{"version", "workspace-count"},
+}
+
+var registryModuleColumns = columnSet[cartographer.PrivateRegistryModule]{
+	all: []column[cartographer.PrivateRegistryModule]{
+		{"id", func(m cartographer.PrivateRegistryModule) interface{} { return m.Id }},
+		{"name", func(m cartographer.PrivateRegistryModule) interface{} { return m.Name }},
+		{"namespace", func(m cartographer.PrivateRegistryModule) interface{} { return m.Namespace }},
+		{"provider", func(m cartographer.PrivateRegistryModule) interface{} { return m.Provider }},
+		{"source", func(m cartographer.PrivateRegistryModule) interface{} { return m.Source() }},
+		{"registry_name", func(m cartographer.PrivateRegistryModule) interface{} { return m.RegistryName }},
+		{"status", func(m cartographer.PrivateRegistryModule) interface{} { return m.Status }},
+		{"no_code", func(m cartographer.PrivateRegistryModule) interface{} { return m.NoCode }},
+		{"publishing_mechanism", func(m cartographer.PrivateRegistryModule) interface{} { return m.PublishingMechanism }},
+		{"vcs_repo", func(m cartographer.PrivateRegistryModule) interface{} { return vcsRepo(m.VcsRepo) }},
+		{"versions", func(m cartographer.PrivateRegistryModule) interface{} { return moduleVersions(m.Versions) }},
+		{"latest_version", func(m cartographer.PrivateRegistryModule) interface{} { return m.LatestVersion }},
+		{"updated_at", func(m cartographer.PrivateRegistryModule) interface{} { return m.UpdatedAt }},
+		{"created_at", func(m cartographer.PrivateRegistryModule) interface{} { return m.CreatedAt }},
+	},
+	defaults: []string{"namespace", "name", "provider", "registry_name", "latest_version", "status"},
+}
+
+// Workspaces builds a table of workspaces with the selected columns, or the default columns if none are selected.
+func Workspaces(workspaces []cartographer.Workspace, columns ...string) (*Table, error) {
+	return workspaceColumns.table(workspaces, columns)
+}
+
+// Modules builds a table of modules with the selected columns, or the default columns if none are selected.
+func Modules(modules []cartographer.Module, columns ...string) (*Table, error) {
+	return moduleColumns.table(modules, columns)
+}
+
+// Providers builds a table of providers with the selected columns, or the default columns if none are selected.
+func Providers(providers []cartographer.Provider, columns ...string) (*Table, error) {
+	return providerColumns.table(providers, columns)
+}
+
+// TFVersions builds a table of Terraform versions with the selected columns, or the default columns if none are
+// selected.
+func TFVersions(versions []cartographer.TFVersion, columns ...string) (*Table, error) {
+	return tfVersionColumns.table(versions, columns)
+}
+
+// RegistryModules builds a table of private registry modules with the selected columns, or the default columns if
+// none are selected.
+func RegistryModules(modules []cartographer.PrivateRegistryModule, columns ...string) (*Table, error) {
+	return registryModuleColumns.table(modules, columns)
+}
+
+// workspaceModules returns the modules of a workspace as name:version pairs.
+func workspaceModules(modules []cartographer.WorkspaceModule) []string {
+	values := []string{}
+	for _, m := range modules {
+		values = append(values, m.Name+":"+m.Version)
+	}
+	return values
+}
+
+// list returns values, or an empty list if it is nil so that JSON Lines output has [] rather than null.
+func list(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+// vcsRepo returns the display identifier of a module's VCS repository, or an empty string if it has none.
+func vcsRepo(repo *cartographer.PrivateRegistryVcsRepo) string {
+	if repo == nil {
+		return ""
+	}
+	return repo.DisplayIdentifier
+}
+
+// moduleVersions returns the version numbers of a registry module.
+func moduleVersions(versions []cartographer.PrivateRegistryModuleVersion) []string {
+	values := []string{}
+	for _, v := range versions {
+		values = append(values, v.Version)
+	}
+	return values
+}
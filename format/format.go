@@ -0,0 +1,265 @@
+// Package format renders cartographer results as aligned text tables, JSON Lines, CSV and GitHub-flavoured Markdown.
+//
+// Each result type has a fixed set of columns named after its JSON fields. A Table holds the selected columns of a
+// slice of results in a stable order and can be sorted before being written in any format.
+package format
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+const (
+	TextTable Format = iota
+	JSONLines
+	CSV
+	Markdown
+)
+
+// Format is an output format for a Table.
+type Format int
+
+func (f Format) String() string {
+	return [...]string{"table", "jsonl", "csv", "markdown"}[f]
+}
+
+// ParseFormat returns the format with the given name: table, jsonl, csv or markdown.
+func ParseFormat(name string) (Format, error) {
+	for f := TextTable; f <= Markdown; f++ {
+		if f.String() == name {
+			return f, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown format %q, expected table, jsonl, csv or markdown", name)
+}
+
+// Table is the selected columns of a slice of results. Values keep their Go types so that JSON Lines output and
+// sorting can use them, and are converted to text for the other formats.
+type Table struct {
+	Columns []string
+	rows    [][]interface{}
+}
+
+// Len returns the number of rows in the table.
+func (t *Table) Len() int {
+	return len(t.rows)
+}
+
+// SortBy sorts the rows by the values of a column, keeping the existing order of equal rows. Numbers and times are
+// compared by value and everything else by its text.
+func (t *Table) SortBy(column string, descending bool) error {
+	i := t.columnIndex(column)
+	if i < 0 {
+		return fmt.Errorf("unknown sort column %q", column)
+	}
+
+	sort.SliceStable(t.rows, func(a, b int) bool {
+		if descending {
+			return less(t.rows[b][i], t.rows[a][i])
+		}
+		return less(t.rows[a][i], t.rows[b][i])
+	})
+	return nil
+}
+
+// columnIndex returns the index of a column, or -1 if the table does not have it.
+func (t *Table) columnIndex(column string) int {
+	for i, c := range t.Columns {
+		if c == column {
+			return i
+		}
+	}
+	return -1
+}
+
+// Write writes the table in the given format.
+func (t *Table) Write(w io.Writer, f Format) error {
+	switch f {
+	case TextTable:
+		return t.writeText(w)
+	case JSONLines:
+		return t.writeJSONLines(w)
+	case CSV:
+		return t.writeCSV(w)
+	case Markdown:
+		return t.writeMarkdown(w)
+	default:
+		return fmt.Errorf("unknown format %d", f)
+	}
+}
+
+// writeText writes the table with upper case headers and columns aligned with spaces.
+func (t *Table) writeText(w io.Writer) error {
+	var b strings.Builder
+	tw := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	headers := make([]string, len(t.Columns))
+	for i, c := range t.Columns {
+		headers[i] = strings.ToUpper(c)
+	}
+	fmt.Fprintln(tw, strings.Join(headers, "\t"))
+
+	for _, row := range t.rows {
+		cells := make([]string, len(row))
+		for i, v := range row {
+			// Tabs and newlines would break the alignment.
+			cells[i] = strings.NewReplacer("\t", " ", "\n", " ").Replace(text(v))
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	// Rows ending in empty cells are padded to the width of the table, so trailing spaces are trimmed.
+	lines := strings.Split(b.String(), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	_, err := io.WriteString(w, strings.Join(lines, "\n"))
+	return err
+}
+
+// writeJSONLines writes one JSON object per row with keys in column order.
+func (t *Table) writeJSONLines(w io.Writer) error {
+	for _, row := range t.rows {
+		var b strings.Builder
+		b.WriteString("{")
+		for i, v := range row {
+			key, err := json.Marshal(t.Columns[i])
+			if err != nil {
+				return err
+			}
+			value, err := json.Marshal(jsonValue(v))
+			if err != nil {
+				return err
+			}
+			if i > 0 {
+				b.WriteString(",")
+			}
+			b.Write(key)
+			b.WriteString(":")
+			b.Write(value)
+		}
+		b.WriteString("}\n")
+
+		if _, err := io.WriteString(w, b.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeCSV writes the table as CSV with a header row.
+func (t *Table) writeCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(t.Columns); err != nil {
+		return err
+	}
+	for _, row := range t.rows {
+		cells := make([]string, len(row))
+		for i, v := range row {
+			cells[i] = text(v)
+		}
+		if err := cw.Write(cells); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// markdownEscaper escapes text so that it stays within a Markdown table cell.
+var markdownEscaper = strings.NewReplacer("|", `\|`, "\r\n", "<br>", "\n", "<br>")
+
+// writeMarkdown writes the table as a GitHub-flavoured Markdown table.
+func (t *Table) writeMarkdown(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("| " + strings.Join(t.Columns, " | ") + " |\n")
+	b.WriteString("|" + strings.Repeat(" --- |", len(t.Columns)) + "\n")
+
+	for _, row := range t.rows {
+		cells := make([]string, len(row))
+		for i, v := range row {
+			cells[i] = markdownEscaper.Replace(text(v))
+		}
+		b.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// jsonValue returns the value to encode in JSON Lines output. Zero times are encoded as null, matching the empty text
+// they are shown as in the other formats.
+func jsonValue(v interface{}) interface{} {
+	if t, ok := v.(time.Time); ok && t.IsZero() {
+		return nil
+	}
+	return v
+}
+
+// text returns the text form of a value: times in RFC 3339, nil pointers as empty and lists joined by commas.
+func text(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case *string:
+		if v == nil {
+			return ""
+		}
+		return *v
+	case int:
+		return strconv.Itoa(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+		return v.Format(time.RFC3339)
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return text(*v)
+	case []string:
+		return strings.Join(v, ", ")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// less compares two values of the same column.
+func less(a, b interface{}) bool {
+	switch a := a.(type) {
+	case int:
+		if b, ok := b.(int); ok {
+			return a < b
+		}
+	case bool:
+		if b, ok := b.(bool); ok {
+			return !a && b
+		}
+	case time.Time:
+		if b, ok := b.(time.Time); ok {
+			return a.Before(b)
+		}
+	case *time.Time:
+		if b, ok := b.(*time.Time); ok {
+			if a == nil || b == nil {
+				return a == nil && b != nil
+			}
+			return a.Before(*b)
+		}
+	}
+	return text(a) < text(b)
+}
@@ -0,0 +1,164 @@
+package format
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/majesticbeast/cartographer"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+// checkGolden renders a table in every format and compares the output with testdata/<name>.golden.
+func checkGolden(t *testing.T, name string, table *Table) {
+	t.Helper()
+
+	var b bytes.Buffer
+	for f := TextTable; f <= Markdown; f++ {
+		b.WriteString("--- " + f.String() + " ---\n")
+		if err := table.Write(&b, f); err != nil {
+			t.Fatalf("Write(%v) returned an error: %v", f, err)
+		}
+	}
+
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.WriteFile(path, b.Bytes(), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading golden file: %v", err)
+	}
+	if !bytes.Equal(b.Bytes(), expected) {
+		t.Errorf("output for %s does not match %s:\n%s", name, path, b.String())
+	}
+}
+
+func TestWorkspaces(t *testing.T) {
+	repo := "github.com/test/network"
+	appliedAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	workspaces := []cartographer.Workspace{
+		{
+			WorkspaceName:             "network",
+			ProjectName:               "infra | core",
+			WorkspaceTerraformVersion: "1.6.0",
+			ChecksFailed:              2,
+			CurrentRunStatus:          "applied",
+			CurrentRunAppliedAt:       &appliedAt,
+			VcsRepoIdentifier:         &repo,
+			Providers:                 "aws,random",
+			Modules:                   []cartographer.WorkspaceModule{{Name: "vpc", Version: "1.0.0"}, {Name: "iam", Version: "2.0.0"}},
+		},
+		{
+			WorkspaceName:             "app",
+			ProjectName:               "apps",
+			WorkspaceTerraformVersion: "1.5.7",
+			Drifted:                   true,
+			CurrentRunStatus:          "planned",
+		},
+	}
+
+	table, err := Workspaces(workspaces)
+	if err != nil {
+		t.Fatalf("Workspaces() returned an error: %v", err)
+	}
+	checkGolden(t, "workspaces", table)
+
+	table, err = Workspaces(workspaces, "workspace-name", "current-run-applied-at", "vcs-repo-identifier", "modules", "providers")
+	if err != nil {
+		t.Fatalf("Workspaces() returned an error: %v", err)
+	}
+	if err := table.SortBy("current-run-applied-at", false); err != nil {
+		t.Fatalf("SortBy() returned an error: %v", err)
+	}
+	checkGolden(t, "workspaces_columns", table)
+}
+
+func TestModulesAndProviders(t *testing.T) {
+	modules := []cartographer.Module{
+		{Name: "vpc", Source: "app.terraform.io/test/vpc/aws", Version: "1.0.0", WorkspaceCount: 2, Workspaces: "network,app"},
+		{Name: "iam", Source: "app.terraform.io/test/iam/aws", Version: "2.0.0", WorkspaceCount: 10, Workspaces: "network"},
+	}
+	table, err := Modules(modules)
+	if err != nil {
+		t.Fatalf("Modules() returned an error: %v", err)
+	}
+	if err := table.SortBy("workspace-count", true); err != nil {
+		t.Fatalf("SortBy() returned an error: %v", err)
+	}
+	checkGolden(t, "modules", table)
+
+	providers := []cartographer.Provider{
+		{Name: "aws", Source: "hashicorp/aws", Version: "5.0.0", RegistryType: "public", WorkspaceCount: 1, Workspaces: "app"},
+	}
+	table, err = Providers(providers, "source", "version", "registry-type", "workspaces")
+	if err != nil {
+		t.Fatalf("Providers() returned an error: %v", err)
+	}
+	checkGolden(t, "providers", table)
+}
+
+func TestTFVersionsAndRegistryModules(t *testing.T) {
+	versions := []cartographer.TFVersion{
+		{Version: "1.6.0", WorkspaceCount: 3, Workspaces: "network,app,dns"},
+		{Version: "1.5.7", WorkspaceCount: 1, Workspaces: "legacy"},
+	}
+	table, err := TFVersions(versions, "version", "workspace-count", "workspaces")
+	if err != nil {
+		t.Fatalf("TFVersions() returned an error: %v", err)
+	}
+	checkGolden(t, "tf_versions", table)
+
+	modules := []cartographer.PrivateRegistryModule{
+		{
+			Name:          "vpc",
+			Namespace:     "test",
+			Provider:      "aws",
+			RegistryName:  "private",
+			Status:        "setup_complete",
+			LatestVersion: "1.1.0",
+			VcsRepo:       &cartographer.PrivateRegistryVcsRepo{DisplayIdentifier: "test/terraform-aws-vpc"},
+			Versions:      []cartographer.PrivateRegistryModuleVersion{{Version: "1.1.0", Status: "ok"}, {Version: "1.0.0", Status: "ok"}},
+			UpdatedAt:     time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			Name:         "consul",
+			Namespace:    "hashicorp",
+			Provider:     "aws",
+			RegistryName: "public",
+		},
+	}
+	table, err = RegistryModules(modules, "source", "vcs_repo", "versions", "latest_version", "updated_at")
+	if err != nil {
+		t.Fatalf("RegistryModules() returned an error: %v", err)
+	}
+	checkGolden(t, "registry_modules", table)
+}
+
+func TestColumnErrors(t *testing.T) {
+	if _, err := Modules(nil, "name", "colour"); err == nil {
+		t.Errorf("Modules() with an unknown column expected an error")
+	}
+
+	table, err := Modules(nil)
+	if err != nil {
+		t.Fatalf("Modules() returned an error: %v", err)
+	}
+	if err := table.SortBy("colour", false); err == nil {
+		t.Errorf("SortBy() with an unknown column expected an error")
+	}
+
+	if f, err := ParseFormat("markdown"); err != nil || f != Markdown {
+		t.Errorf("ParseFormat(markdown) returned %v, %v", f, err)
+	}
+	if _, err := ParseFormat("yaml"); err == nil {
+		t.Errorf("ParseFormat(yaml) expected an error")
+	}
+}
@@ -0,0 +1,531 @@
+package cartographer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	WorkspaceNode NodeKind = iota
+	ProjectNode
+	ModuleNode
+	ProviderNode
+	VcsRepoNode
+)
+
+// NodeKind identifies the type of entity a Node represents.
+type NodeKind int
+
+func (k NodeKind) String() string {
+	return [...]string{"workspace", "project", "module", "provider", "vcs-repo"}[k]
+}
+
+// MarshalText encodes the kind as its string form.
+func (k NodeKind) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+// UnmarshalText decodes a kind from its string form.
+func (k *NodeKind) UnmarshalText(text []byte) error {
+	for candidate := WorkspaceNode; candidate <= VcsRepoNode; candidate++ {
+		if candidate.String() == string(text) {
+			*k = candidate
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown node kind %q", text)
+}
+
+const (
+	InProject EdgeKind = iota
+	FromVcsRepo
+	UsesModule
+	UsesProvider
+	RequiresProvider
+)
+
+// EdgeKind identifies the relationship an Edge represents.
+type EdgeKind int
+
+func (k EdgeKind) String() string {
+	return [...]string{"in-project", "from-vcs-repo", "uses-module", "uses-provider", "requires-provider"}[k]
+}
+
+// MarshalText encodes the kind as its string form.
+func (k EdgeKind) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+// UnmarshalText decodes a kind from its string form.
+func (k *EdgeKind) UnmarshalText(text []byte) error {
+	for candidate := InProject; candidate <= RequiresProvider; candidate++ {
+		if candidate.String() == string(text) {
+			*k = candidate
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown edge kind %q", text)
+}
+
+// Node is an entity in the organization inventory graph. Attributes hold kind specific details such as the Terraform
+// version of a workspace.
+type Node struct {
+	ID         string            `json:"id"`
+	Kind       NodeKind          `json:"kind"`
+	Label      string            `json:"label"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// Edge is a directed, typed relationship between two nodes. Version is set on module and provider edges to the version
+// the workspace uses, and on RequiresProvider edges to the module version that requires the provider.
+type Edge struct {
+	From    string   `json:"from"`
+	To      string   `json:"to"`
+	Kind    EdgeKind `json:"kind"`
+	Version string   `json:"version,omitempty"`
+}
+
+// Graph is an in-memory graph of an organization's workspaces and the projects, VCS repositories, modules and
+// providers they relate to. Edges point from a workspace to the entities it belongs to or uses, and from modules to the
+// providers they require when that is known.
+type Graph struct {
+	nodes map[string]*Node
+	out   map[string][]Edge
+	in    map[string][]Edge
+}
+
+// WorkspaceNodeID returns the ID of the node representing the named workspace.
+func WorkspaceNodeID(name string) string { return "workspace:" + name }
+
+// ProjectNodeID returns the ID of the node representing the project with the given external ID.
+func ProjectNodeID(externalId string) string { return "project:" + externalId }
+
+// ModuleNodeID returns the ID of the node representing the module with the given source.
+func ModuleNodeID(source string) string { return "module:" + normalizeModuleSource(source) }
+
+// ProviderNodeID returns the ID of the node representing the provider with the given source.
+func ProviderNodeID(source string) string { return "provider:" + source }
+
+// VcsRepoNodeID returns the ID of the node representing the VCS repository with the given identifier.
+func VcsRepoNodeID(identifier string) string { return "vcs-repo:" + identifier }
+
+// Graph retrieves the organization's workspaces, modules and providers and builds an inventory graph from them. The
+// providers required by each version of the organization's private registry modules in use are retrieved from the
+// registry and added as RequiresProvider edges, which makes one request per module version.
+func (c *Cartographer) Graph() (*Graph, error) {
+	workspaces, err := c.Workspaces(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	modules, err := c.Modules(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	providers, err := c.Providers(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	g := NewGraph(workspaces, modules, providers)
+	if err := c.addModuleRequirements(g, modules); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// addModuleRequirements adds the providers required by every version of a private registry module in use to the
+// graph. Modules from other registries are skipped, as are versions the registry does not know.
+func (c *Cartographer) addModuleRequirements(g *Graph, modules []Module) error {
+	seen := make(map[string]bool)
+	for _, m := range modules {
+		namespace, name, provider, ok := privateRegistryAddress(m.Source)
+		if !ok || !strings.EqualFold(namespace, c.orgName) {
+			continue
+		}
+		key := strings.ToLower(namespace+"/"+name+"/"+provider) + "@" + m.Version
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		details, err := c.RegistryModuleVersion(namespace, name, provider, m.Version)
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == 404 {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("module %s %s: %w", m.Source, m.Version, err)
+		}
+		g.AddModuleRequirements(m.Source, *details)
+	}
+	return nil
+}
+
+// privateRegistryAddress splits a private registry module source such as app.terraform.io/org/vpc/aws//modules/subnet
+// into its namespace, name and provider. It reports false for sources from anywhere else.
+func privateRegistryAddress(source string) (namespace, name, provider string, ok bool) {
+	s := strings.TrimSpace(source)
+	if i := strings.Index(s, "//"); i >= 0 {
+		s = s[:i]
+	}
+	parts := strings.Split(s, "/")
+	if len(parts) != 4 || !strings.EqualFold(parts[0], DefaultHostname) {
+		return "", "", "", false
+	}
+	return parts[1], parts[2], parts[3], true
+}
+
+// NewGraph builds an inventory graph from Explorer results. Workspaces are linked to their project and VCS repository,
+// and module and provider rows are linked to every workspace listed as using them.
+func NewGraph(workspaces []Workspace, modules []Module, providers []Provider) *Graph {
+	g := &Graph{
+		nodes: make(map[string]*Node),
+		out:   make(map[string][]Edge),
+		in:    make(map[string][]Edge),
+	}
+
+	for _, w := range workspaces {
+		id := WorkspaceNodeID(w.WorkspaceName)
+		g.AddNode(Node{
+			ID:    id,
+			Kind:  WorkspaceNode,
+			Label: w.WorkspaceName,
+			Attributes: map[string]string{
+				"external-id":       w.ExternalId,
+				"terraform-version": w.WorkspaceTerraformVersion,
+				"drifted":           fmt.Sprint(w.Drifted),
+				"checks-failed":     fmt.Sprint(w.ChecksFailed + w.ChecksErrored),
+			},
+		})
+
+		if w.ProjectExternalId != "" {
+			g.AddNode(Node{ID: ProjectNodeID(w.ProjectExternalId), Kind: ProjectNode, Label: w.ProjectName})
+			g.AddEdge(Edge{From: id, To: ProjectNodeID(w.ProjectExternalId), Kind: InProject})
+		}
+
+		if w.VcsRepoIdentifier != nil && *w.VcsRepoIdentifier != "" {
+			g.AddNode(Node{ID: VcsRepoNodeID(*w.VcsRepoIdentifier), Kind: VcsRepoNode, Label: *w.VcsRepoIdentifier})
+			g.AddEdge(Edge{From: id, To: VcsRepoNodeID(*w.VcsRepoIdentifier), Kind: FromVcsRepo})
+		}
+	}
+
+	for _, m := range modules {
+		moduleId := ModuleNodeID(m.Source)
+		if _, ok := g.nodes[moduleId]; !ok {
+			g.AddNode(Node{ID: moduleId, Kind: ModuleNode, Label: m.Name, Attributes: map[string]string{"source": m.Source}})
+		}
+		for _, name := range m.WorkspaceNames() {
+			g.ensureWorkspace(name)
+			g.AddEdge(Edge{From: WorkspaceNodeID(name), To: moduleId, Kind: UsesModule, Version: m.Version})
+		}
+	}
+
+	for _, p := range providers {
+		providerId := ProviderNodeID(p.Source)
+		if _, ok := g.nodes[providerId]; !ok {
+			g.AddNode(Node{ID: providerId, Kind: ProviderNode, Label: p.Name, Attributes: map[string]string{"source": p.Source}})
+		}
+		for _, name := range p.WorkspaceNames() {
+			g.ensureWorkspace(name)
+			g.AddEdge(Edge{From: WorkspaceNodeID(name), To: providerId, Kind: UsesProvider, Version: p.Version})
+		}
+	}
+
+	return g
+}
+
+// AddModuleRequirements adds RequiresProvider edges from the module with the given source to every provider required by
+// the root module or a submodule of a registry module version.
+func (g *Graph) AddModuleRequirements(source string, details RegistryModuleVersionDetails) {
+	moduleId := ModuleNodeID(source)
+	if _, ok := g.nodes[moduleId]; !ok {
+		g.AddNode(Node{ID: moduleId, Kind: ModuleNode, Label: details.Name, Attributes: map[string]string{"source": source}})
+	}
+
+	for _, m := range append([]RegistryModuleDetails{details.Root}, details.Submodules...) {
+		for _, p := range m.ProviderDependencies {
+			providerSource := p.Source
+			if providerSource == "" {
+				providerSource = p.Namespace + "/" + p.Name
+			}
+			providerId := ProviderNodeID(providerSource)
+			if _, ok := g.nodes[providerId]; !ok {
+				g.AddNode(Node{ID: providerId, Kind: ProviderNode, Label: p.Name, Attributes: map[string]string{"source": providerSource}})
+			}
+			g.AddEdge(Edge{From: moduleId, To: providerId, Kind: RequiresProvider, Version: details.Version})
+		}
+	}
+}
+
+// ensureWorkspace adds a bare workspace node if the named workspace is not already in the graph.
+func (g *Graph) ensureWorkspace(name string) {
+	if _, ok := g.nodes[WorkspaceNodeID(name)]; !ok {
+		g.AddNode(Node{ID: WorkspaceNodeID(name), Kind: WorkspaceNode, Label: name})
+	}
+}
+
+// AddNode adds a node to the graph, replacing any existing node with the same ID.
+func (g *Graph) AddNode(n Node) {
+	g.nodes[n.ID] = &n
+}
+
+// AddEdge adds an edge to the graph. Duplicate edges are ignored.
+func (g *Graph) AddEdge(e Edge) {
+	for _, existing := range g.out[e.From] {
+		if existing == e {
+			return
+		}
+	}
+	g.out[e.From] = append(g.out[e.From], e)
+	g.in[e.To] = append(g.in[e.To], e)
+}
+
+// Node returns the node with the given ID and whether it exists.
+func (g *Graph) Node(id string) (Node, bool) {
+	n, ok := g.nodes[id]
+	if !ok {
+		return Node{}, false
+	}
+	return *n, true
+}
+
+// Nodes returns every node in the graph of the given kinds, or every node when no kinds are given, sorted by ID.
+func (g *Graph) Nodes(kinds ...NodeKind) []Node {
+	var nodes []Node
+	for _, n := range g.nodes {
+		if matchesKinds(n.Kind, kinds) {
+			nodes = append(nodes, *n)
+		}
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID < nodes[j].ID })
+	return nodes
+}
+
+// Edges returns every edge in the graph sorted by source, target and kind.
+func (g *Graph) Edges() []Edge {
+	var edges []Edge
+	for _, out := range g.out {
+		edges = append(edges, out...)
+	}
+	sortEdges(edges)
+	return edges
+}
+
+// OutEdges returns the edges leaving the node with the given ID.
+func (g *Graph) OutEdges(id string) []Edge {
+	edges := append([]Edge(nil), g.out[id]...)
+	sortEdges(edges)
+	return edges
+}
+
+// InEdges returns the edges entering the node with the given ID.
+func (g *Graph) InEdges(id string) []Edge {
+	edges := append([]Edge(nil), g.in[id]...)
+	sortEdges(edges)
+	return edges
+}
+
+// Dependencies returns every node reachable from the node with the given ID by following edges forwards, such as the
+// modules, providers and project of a workspace. The result is limited to the given kinds when any are given.
+func (g *Graph) Dependencies(id string, kinds ...NodeKind) []Node {
+	return g.reachable(id, g.out, func(e Edge) string { return e.To }, kinds)
+}
+
+// Dependents returns every node that can reach the node with the given ID by following edges forwards, such as the
+// workspaces using a module. The result is limited to the given kinds when any are given.
+func (g *Graph) Dependents(id string, kinds ...NodeKind) []Node {
+	return g.reachable(id, g.in, func(e Edge) string { return e.From }, kinds)
+}
+
+// reachable performs a breadth first traversal from id over the given adjacency and returns the visited nodes of the
+// given kinds sorted by ID, excluding the starting node.
+func (g *Graph) reachable(id string, adjacency map[string][]Edge, next func(Edge) string, kinds []NodeKind) []Node {
+	visited := map[string]bool{id: true}
+	queue := []string{id}
+	var nodes []Node
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, e := range adjacency[current] {
+			n := next(e)
+			if visited[n] {
+				continue
+			}
+			visited[n] = true
+			queue = append(queue, n)
+			if node, ok := g.nodes[n]; ok && matchesKinds(node.Kind, kinds) {
+				nodes = append(nodes, *node)
+			}
+		}
+	}
+
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID < nodes[j].ID })
+	return nodes
+}
+
+// ShortestPath returns the IDs of the nodes on a shortest path between two nodes, including both ends. Edges are
+// traversed in either direction, so a module and a provider are connected through the workspaces that use both. It
+// returns nil if either node is missing or no path exists.
+func (g *Graph) ShortestPath(from, to string) []string {
+	if _, ok := g.nodes[from]; !ok {
+		return nil
+	}
+	if _, ok := g.nodes[to]; !ok {
+		return nil
+	}
+
+	previous := map[string]string{from: ""}
+	queue := []string{from}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if current == to {
+			break
+		}
+
+		var neighbours []string
+		for _, e := range g.out[current] {
+			neighbours = append(neighbours, e.To)
+		}
+		for _, e := range g.in[current] {
+			neighbours = append(neighbours, e.From)
+		}
+		sort.Strings(neighbours)
+
+		for _, n := range neighbours {
+			if _, seen := previous[n]; seen {
+				continue
+			}
+			previous[n] = current
+			queue = append(queue, n)
+		}
+	}
+
+	if _, ok := previous[to]; !ok {
+		return nil
+	}
+
+	var path []string
+	for n := to; n != ""; n = previous[n] {
+		path = append([]string{n}, path...)
+	}
+	return path
+}
+
+// ModuleProvidersByProject answers which providers a module pulls into which projects. For every workspace using the
+// module with the given source, the providers the module version it uses requires are attributed to the workspace's
+// project. Only RequiresProvider edges are followed, so providers a workspace uses on its own are not counted and
+// module versions without known requirements contribute nothing. The result maps project names to sorted provider
+// sources.
+func (g *Graph) ModuleProvidersByProject(source string) map[string][]string {
+	moduleId := ModuleNodeID(source)
+
+	required := make(map[string][]string)
+	for _, e := range g.out[moduleId] {
+		if e.Kind == RequiresProvider {
+			required[e.Version] = append(required[e.Version], e.To)
+		}
+	}
+
+	byProject := make(map[string]map[string]bool)
+	for _, e := range g.in[moduleId] {
+		if e.Kind != UsesModule || len(required[e.Version]) == 0 {
+			continue
+		}
+
+		project := ""
+		for _, we := range g.out[e.From] {
+			if we.Kind == InProject {
+				project = g.nodes[we.To].Label
+			}
+		}
+
+		if byProject[project] == nil {
+			byProject[project] = make(map[string]bool)
+		}
+		for _, p := range required[e.Version] {
+			byProject[project][strings.TrimPrefix(p, ProviderNodeID(""))] = true
+		}
+	}
+
+	result := make(map[string][]string)
+	for project, providers := range byProject {
+		for p := range providers {
+			result[project] = append(result[project], p)
+		}
+		sort.Strings(result[project])
+	}
+	return result
+}
+
+// graphJSON is the serialised form of a Graph.
+type graphJSON struct {
+	Nodes []Node `json:"nodes"`
+	Edges []Edge `json:"edges"`
+}
+
+// MarshalJSON serialises the graph as sorted lists of nodes and edges.
+func (g *Graph) MarshalJSON() ([]byte, error) {
+	return json.Marshal(graphJSON{Nodes: g.Nodes(), Edges: g.Edges()})
+}
+
+// UnmarshalJSON rebuilds a graph from the form produced by MarshalJSON.
+func (g *Graph) UnmarshalJSON(data []byte) error {
+	var decoded graphJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	g.nodes = make(map[string]*Node)
+	g.out = make(map[string][]Edge)
+	g.in = make(map[string][]Edge)
+	for _, n := range decoded.Nodes {
+		g.AddNode(n)
+	}
+	for _, e := range decoded.Edges {
+		if _, ok := g.nodes[e.From]; !ok {
+			return fmt.Errorf("edge references unknown node %q", e.From)
+		}
+		if _, ok := g.nodes[e.To]; !ok {
+			return fmt.Errorf("edge references unknown node %q", e.To)
+		}
+		g.AddEdge(e)
+	}
+	return nil
+}
+
+// matchesKinds reports whether kind is one of kinds, treating an empty list as matching every kind.
+func matchesKinds(kind NodeKind, kinds []NodeKind) bool {
+	if len(kinds) == 0 {
+		return true
+	}
+	for _, k := range kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// sortEdges sorts edges by source, target, kind and version.
+func sortEdges(edges []Edge) {
+	sort.Slice(edges, func(i, j int) bool {
+		a, b := edges[i], edges[j]
+		if a.From != b.From {
+			return a.From < b.From
+		}
+		if a.To != b.To {
+			return a.To < b.To
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Version < b.Version
+	})
+}
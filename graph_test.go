@@ -0,0 +1,167 @@
+package cartographer
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func testGraph() *Graph {
+	repo := "github.com/majesticbeast/network"
+	workspaces := []Workspace{
+		{WorkspaceName: "network-prod", ProjectName: "network", ProjectExternalId: "prj-1", VcsRepoIdentifier: &repo},
+		{WorkspaceName: "app", ProjectName: "apps", ProjectExternalId: "prj-2"},
+	}
+	modules := []Module{
+		{Name: "vpc", Source: "app.terraform.io/myOrgName/vpc/aws", Version: "1.0.0", Workspaces: "network-prod,app"},
+		{Name: "tags", Source: "app.terraform.io/myOrgName/tags/null", Version: "0.1.0", Workspaces: "app"},
+	}
+	providers := []Provider{
+		{Name: "aws", Source: "hashicorp/aws", Version: "5.0.0", Workspaces: "network-prod,app"},
+		{Name: "random", Source: "hashicorp/random", Version: "3.5.1", Workspaces: "app"},
+	}
+
+	return NewGraph(workspaces, modules, providers)
+}
+
+func TestGraphTraversal(t *testing.T) {
+	g := testGraph()
+
+	if got := len(g.Nodes()); got != 9 {
+		t.Errorf("Nodes() returned %v nodes, expected 9", got)
+	}
+	if got := len(g.Nodes(ProjectNode)); got != 2 {
+		t.Errorf("Nodes(ProjectNode) returned %v nodes, expected 2", got)
+	}
+
+	deps := g.Dependencies(WorkspaceNodeID("app"), ModuleNode)
+	if len(deps) != 2 || deps[0].Label != "tags" || deps[1].Label != "vpc" {
+		t.Errorf("Dependencies() returned %+v, expected tags and vpc", deps)
+	}
+
+	dependents := g.Dependents(ModuleNodeID("app.terraform.io/myOrgName/vpc/aws"))
+	if len(dependents) != 2 || dependents[0].ID != WorkspaceNodeID("app") {
+		t.Errorf("Dependents() returned %+v, expected both workspaces", dependents)
+	}
+
+	path := g.ShortestPath(ModuleNodeID("app.terraform.io/myOrgName/tags/null"), ProjectNodeID("prj-1"))
+	expected := []string{
+		"module:app.terraform.io/myorgname/tags/null",
+		"workspace:app",
+		"module:app.terraform.io/myorgname/vpc/aws",
+		"workspace:network-prod",
+		"project:prj-1",
+	}
+	if !reflect.DeepEqual(path, expected) {
+		t.Errorf("ShortestPath() returned %v, expected %v", path, expected)
+	}
+	if g.ShortestPath("workspace:missing", ProjectNodeID("prj-1")) != nil {
+		t.Errorf("ShortestPath() from a missing node expected nil")
+	}
+
+	// The module requires the null provider, so the random provider the app workspace uses on its own is not counted.
+	g.AddModuleRequirements("app.terraform.io/myOrgName/vpc/aws", RegistryModuleVersionDetails{
+		Name:    "vpc",
+		Version: "1.0.0",
+		Root: RegistryModuleDetails{
+			ProviderDependencies: []RegistryModuleProviderDependency{{Name: "aws", Namespace: "hashicorp", Source: "hashicorp/aws"}},
+		},
+		Submodules: []RegistryModuleDetails{{
+			ProviderDependencies: []RegistryModuleProviderDependency{{Name: "null", Namespace: "hashicorp"}},
+		}},
+	})
+	g.AddModuleRequirements("app.terraform.io/myOrgName/vpc/aws", RegistryModuleVersionDetails{
+		Version: "2.0.0",
+		Root: RegistryModuleDetails{
+			ProviderDependencies: []RegistryModuleProviderDependency{{Name: "time", Namespace: "hashicorp", Source: "hashicorp/time"}},
+		},
+	})
+
+	byProject := g.ModuleProvidersByProject("app.terraform.io/myOrgName/vpc/aws")
+	expectedProviders := map[string][]string{
+		"apps":    {"hashicorp/aws", "hashicorp/null"},
+		"network": {"hashicorp/aws", "hashicorp/null"},
+	}
+	if !reflect.DeepEqual(byProject, expectedProviders) {
+		t.Errorf("ModuleProvidersByProject() returned %v, expected %v", byProject, expectedProviders)
+	}
+	if got := g.ModuleProvidersByProject("app.terraform.io/myOrgName/tags/null"); len(got) != 0 {
+		t.Errorf("ModuleProvidersByProject() for a module without requirements returned %v, expected none", got)
+	}
+}
+
+func TestCartographerGraph(t *testing.T) {
+	page := `"links": {"self": "test", "first": "test", "last": "test", "prev": null, "next": null},
+		"meta": {"pagination": {"current-page": 1, "page-size": 100, "next-page": null, "prev-page": null, "total-pages": 1, "total-count": 1}}`
+	responses := map[string]string{
+		"workspaces": `{"data": [{"attributes": {"workspace-name": "app", "project-name": "apps", "project-external-id": "prj-1", "workspace-updated-at": "2024-01-01T00:00:00Z"}, "id": "1", "type": "t"}], ` + page + `}`,
+		"modules": `{"data": [
+			{"attributes": {"name": "vpc", "source": "app.terraform.io/test/vpc/aws", "version": "1.0.0", "workspaces": "app"}, "id": "2", "type": "t"},
+			{"attributes": {"name": "gone", "source": "app.terraform.io/test/gone/aws", "version": "0.1.0", "workspaces": "app"}, "id": "3", "type": "t"},
+			{"attributes": {"name": "consul", "source": "hashicorp/consul/aws", "version": "0.1.0", "workspaces": "app"}, "id": "4", "type": "t"}
+		], ` + page + `}`,
+		"providers": `{"data": [{"attributes": {"name": "random", "source": "hashicorp/random", "version": "3.5.1", "workspaces": "app"}, "id": "5", "type": "t"}], ` + page + `}`,
+	}
+
+	var registryPaths []string
+	mockClient := &MockClient{
+		MockDo: func(req *http.Request) (*http.Response, error) {
+			if strings.HasPrefix(req.URL.Path, "/api/registry/v1/modules/") {
+				registryPaths = append(registryPaths, req.URL.Path)
+				if strings.Contains(req.URL.Path, "/gone/") {
+					return &http.Response{StatusCode: 404, Body: io.NopCloser(strings.NewReader(""))}, nil
+				}
+				body := `{"version": "1.0.0", "root": {"provider_dependencies": [{"name": "aws", "namespace": "hashicorp", "source": "hashicorp/aws"}]}}`
+				return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(body))}, nil
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(responses[req.URL.Query().Get("type")])),
+			}, nil
+		},
+	}
+
+	g, err := NewCartographerWithClient("test", "test", mockClient).Graph()
+	if err != nil {
+		t.Fatalf("Graph() returned an error: %v", err)
+	}
+
+	expectedPaths := []string{"/api/registry/v1/modules/test/vpc/aws/1.0.0", "/api/registry/v1/modules/test/gone/aws/0.1.0"}
+	if !reflect.DeepEqual(registryPaths, expectedPaths) {
+		t.Errorf("Graph() requested %v, expected only the private registry modules %v", registryPaths, expectedPaths)
+	}
+
+	byProject := g.ModuleProvidersByProject("app.terraform.io/test/vpc/aws")
+	if expected := map[string][]string{"apps": {"hashicorp/aws"}}; !reflect.DeepEqual(byProject, expected) {
+		t.Errorf("ModuleProvidersByProject() returned %v, expected %v", byProject, expected)
+	}
+}
+
+func TestGraphJSON(t *testing.T) {
+	g := testGraph()
+
+	data, err := json.Marshal(g)
+	if err != nil {
+		t.Fatalf("json.Marshal() returned an error: %v", err)
+	}
+
+	var decoded Graph
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal() returned an error: %v", err)
+	}
+
+	if !reflect.DeepEqual(decoded.Nodes(), g.Nodes()) {
+		t.Errorf("decoded nodes %+v do not match %+v", decoded.Nodes(), g.Nodes())
+	}
+	if !reflect.DeepEqual(decoded.Edges(), g.Edges()) {
+		t.Errorf("decoded edges %+v do not match %+v", decoded.Edges(), g.Edges())
+	}
+
+	invalid := `{"nodes": [], "edges": [{"from": "workspace:a", "to": "project:b", "kind": "in-project"}]}`
+	if err := json.Unmarshal([]byte(invalid), &decoded); err == nil {
+		t.Errorf("json.Unmarshal() with a dangling edge expected an error")
+	}
+}
@@ -0,0 +1,174 @@
+package cartographer
+
+import (
+	"sort"
+)
+
+const (
+	checksScoreWeight = 0.6
+	driftScoreWeight  = 0.4
+)
+
+// HealthReport retrieves every workspace in the organization and builds a HealthSummary from them.
+func (c *Cartographer) HealthReport() (*HealthSummary, error) {
+	workspaces, err := c.Workspaces(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewHealthSummary(workspaces), nil
+}
+
+// NewHealthSummary summarises the continuous validation results and drift status of the given workspaces. Workspaces
+// and projects are ranked by the number of failed and errored checks, highest first, then by health score, lowest
+// first.
+func NewHealthSummary(workspaces []Workspace) *HealthSummary {
+	summary := &HealthSummary{}
+	projects := make(map[string]*ProjectHealth)
+
+	for _, w := range workspaces {
+		health := NewWorkspaceHealth(w)
+		summary.Workspaces = append(summary.Workspaces, health)
+		if health.ChecksAllUnknown() {
+			summary.AllChecksUnknown = append(summary.AllChecksUnknown, health)
+		}
+
+		p, ok := projects[w.ProjectName]
+		if !ok {
+			p = &ProjectHealth{ProjectName: w.ProjectName}
+			projects[w.ProjectName] = p
+		}
+		p.Workspaces++
+		p.ChecksPassed += health.ChecksPassed
+		p.ChecksFailed += health.ChecksFailed
+		p.ChecksErrored += health.ChecksErrored
+		p.ChecksUnknown += health.ChecksUnknown
+		p.Score += health.Score
+		if health.ChecksFailed+health.ChecksErrored > 0 {
+			p.FailingWorkspaces++
+		}
+		if health.ChecksAllUnknown() {
+			p.AllChecksUnknownWorkspaces++
+		}
+		if health.Drifted {
+			p.DriftedWorkspaces++
+		}
+	}
+
+	sort.SliceStable(summary.Workspaces, func(i, j int) bool {
+		a, b := summary.Workspaces[i], summary.Workspaces[j]
+		if a.failing() != b.failing() {
+			return a.failing() > b.failing()
+		}
+		if a.Score != b.Score {
+			return a.Score < b.Score
+		}
+		return a.WorkspaceName < b.WorkspaceName
+	})
+
+	sort.SliceStable(summary.AllChecksUnknown, func(i, j int) bool {
+		return summary.AllChecksUnknown[i].WorkspaceName < summary.AllChecksUnknown[j].WorkspaceName
+	})
+
+	for _, p := range projects {
+		p.Score /= float64(p.Workspaces)
+		summary.Projects = append(summary.Projects, *p)
+	}
+	sort.Slice(summary.Projects, func(i, j int) bool {
+		a, b := summary.Projects[i], summary.Projects[j]
+		if a.ChecksFailed+a.ChecksErrored != b.ChecksFailed+b.ChecksErrored {
+			return a.ChecksFailed+a.ChecksErrored > b.ChecksFailed+b.ChecksErrored
+		}
+		if a.Score != b.Score {
+			return a.Score < b.Score
+		}
+		return a.ProjectName < b.ProjectName
+	})
+
+	return summary
+}
+
+// HealthSummary ranks an organization's workspaces and projects by the health of their continuous validation checks
+// and drift status.
+type HealthSummary struct {
+	Workspaces []WorkspaceHealth `json:"workspaces"`
+	Projects   []ProjectHealth   `json:"projects"`
+	// AllChecksUnknown are the workspaces with checks whose results are all unknown.
+	AllChecksUnknown []WorkspaceHealth `json:"all-checks-unknown"`
+}
+
+// WorkspaceHealth represents the check results and drift status of a single workspace along with a combined score.
+type WorkspaceHealth struct {
+	WorkspaceName      string  `json:"workspace-name"`
+	ExternalId         string  `json:"external-id"`
+	ProjectName        string  `json:"project-name"`
+	AllChecksSucceeded bool    `json:"all-checks-succeeded"`
+	ChecksPassed       int     `json:"checks-passed"`
+	ChecksFailed       int     `json:"checks-failed"`
+	ChecksErrored      int     `json:"checks-errored"`
+	ChecksUnknown      int     `json:"checks-unknown"`
+	Drifted            bool    `json:"drifted"`
+	ResourcesDrifted   int     `json:"resources-drifted"`
+	ResourcesUndrifted int     `json:"resources-undrifted"`
+	Score              float64 `json:"score"`
+}
+
+// NewWorkspaceHealth computes the health of a workspace. The score ranges from 0 to 100 and weighs check health at 60%
+// and drift health at 40%. Check health is the share of passing checks, with unknown results counting as half a pass,
+// and is perfect for workspaces without checks. Drift health is the share of undrifted resources, and is zero for a
+// drifted workspace that reports no resource counts.
+func NewWorkspaceHealth(w Workspace) WorkspaceHealth {
+	health := WorkspaceHealth{
+		WorkspaceName:      w.WorkspaceName,
+		ExternalId:         w.ExternalId,
+		ProjectName:        w.ProjectName,
+		AllChecksSucceeded: w.AllChecksSucceeded,
+		ChecksPassed:       w.ChecksPassed,
+		ChecksFailed:       w.ChecksFailed,
+		ChecksErrored:      w.ChecksErrored,
+		ChecksUnknown:      w.ChecksUnknown,
+		Drifted:            w.Drifted,
+		ResourcesDrifted:   w.ResourcesDrifted,
+		ResourcesUndrifted: w.ResourcesUndrifted,
+	}
+
+	checksHealth := 1.0
+	if total := w.ChecksPassed + w.ChecksFailed + w.ChecksErrored + w.ChecksUnknown; total > 0 {
+		checksHealth = (float64(w.ChecksPassed) + float64(w.ChecksUnknown)/2) / float64(total)
+	}
+
+	driftHealth := 1.0
+	if total := w.ResourcesDrifted + w.ResourcesUndrifted; total > 0 {
+		driftHealth = float64(w.ResourcesUndrifted) / float64(total)
+	} else if w.Drifted {
+		driftHealth = 0
+	}
+
+	health.Score = 100 * (checksScoreWeight*checksHealth + driftScoreWeight*driftHealth)
+
+	return health
+}
+
+// ChecksAllUnknown reports whether the workspace has checks and none of them have a known result.
+func (h WorkspaceHealth) ChecksAllUnknown() bool {
+	return h.ChecksUnknown > 0 && h.ChecksPassed+h.ChecksFailed+h.ChecksErrored == 0
+}
+
+// failing returns the number of failed and errored checks.
+func (h WorkspaceHealth) failing() int {
+	return h.ChecksFailed + h.ChecksErrored
+}
+
+// ProjectHealth aggregates the health of the workspaces in a project. Score is the average workspace score.
+type ProjectHealth struct {
+	ProjectName                string  `json:"project-name"`
+	Workspaces                 int     `json:"workspaces"`
+	FailingWorkspaces          int     `json:"failing-workspaces"`
+	AllChecksUnknownWorkspaces int     `json:"all-checks-unknown-workspaces"`
+	DriftedWorkspaces          int     `json:"drifted-workspaces"`
+	ChecksPassed               int     `json:"checks-passed"`
+	ChecksFailed               int     `json:"checks-failed"`
+	ChecksErrored              int     `json:"checks-errored"`
+	ChecksUnknown              int     `json:"checks-unknown"`
+	Score                      float64 `json:"score"`
+}
@@ -0,0 +1,63 @@
+package cartographer
+
+import (
+	"testing"
+)
+
+func TestNewWorkspaceHealth(t *testing.T) {
+	tests := []struct {
+		name      string
+		workspace Workspace
+		expected  float64
+	}{
+		{name: "no checks and no drift", workspace: Workspace{}, expected: 100},
+		{name: "all checks failing", workspace: Workspace{ChecksFailed: 2}, expected: 40},
+		{name: "all checks unknown", workspace: Workspace{ChecksUnknown: 4}, expected: 70},
+		{name: "half drifted", workspace: Workspace{ChecksPassed: 1, Drifted: true, ResourcesDrifted: 5, ResourcesUndrifted: 5}, expected: 80},
+		{name: "drifted without counts", workspace: Workspace{Drifted: true}, expected: 60},
+	}
+
+	for _, tt := range tests {
+		if got := NewWorkspaceHealth(tt.workspace).Score; got != tt.expected {
+			t.Errorf("%s: NewWorkspaceHealth() returned score %v, expected %v", tt.name, got, tt.expected)
+		}
+	}
+}
+
+func TestNewHealthSummary(t *testing.T) {
+	workspaces := []Workspace{
+		{WorkspaceName: "healthy", ProjectName: "network", ChecksPassed: 3, AllChecksSucceeded: true},
+		{WorkspaceName: "unknown", ProjectName: "network", ChecksUnknown: 2},
+		{WorkspaceName: "errored", ProjectName: "compute", ChecksErrored: 1, ChecksPassed: 1},
+		{WorkspaceName: "failing", ProjectName: "compute", ChecksFailed: 3, Drifted: true, ResourcesDrifted: 1, ResourcesUndrifted: 1},
+	}
+
+	summary := NewHealthSummary(workspaces)
+
+	var order []string
+	for _, w := range summary.Workspaces {
+		order = append(order, w.WorkspaceName)
+	}
+	expected := []string{"failing", "errored", "unknown", "healthy"}
+	for i := range expected {
+		if order[i] != expected[i] {
+			t.Fatalf("NewHealthSummary() ranked workspaces %v, expected %v", order, expected)
+		}
+	}
+
+	if len(summary.AllChecksUnknown) != 1 || summary.AllChecksUnknown[0].WorkspaceName != "unknown" {
+		t.Errorf("NewHealthSummary() returned all unknown %+v, expected only 'unknown'", summary.AllChecksUnknown)
+	}
+
+	if len(summary.Projects) != 2 {
+		t.Fatalf("NewHealthSummary() returned %v projects, expected 2", len(summary.Projects))
+	}
+	compute := summary.Projects[0]
+	if compute.ProjectName != "compute" || compute.FailingWorkspaces != 2 || compute.DriftedWorkspaces != 1 {
+		t.Errorf("NewHealthSummary() returned %+v, expected compute first with 2 failing workspaces", compute)
+	}
+	network := summary.Projects[1]
+	if network.AllChecksUnknownWorkspaces != 1 || network.Score != 85 {
+		t.Errorf("NewHealthSummary() returned %+v, expected network with a score of 85", network)
+	}
+}
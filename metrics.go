@@ -0,0 +1,273 @@
+package cartographer
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// metricsNamespace prefixes the names of every exported metric.
+const metricsNamespace = "cartographer_"
+
+// metricsQueries are the queries run by a MetricsCollector, in the order their metrics are exposed.
+var metricsQueries = []string{"workspaces", "modules", "providers"}
+
+// labelEscaper escapes label values as required by the Prometheus text exposition format.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// metricFamily is a named metric and its samples, rendered in the Prometheus text exposition format.
+type metricFamily struct {
+	name    string
+	help    string
+	kind    string
+	samples []metricSample
+}
+
+// metricSample is one labelled value of a metric family. Labels are name and value pairs.
+type metricSample struct {
+	labels [][2]string
+	value  float64
+}
+
+// MetricsCollector periodically queries an organization and serves the results as Prometheus metrics. Metrics from
+// the last successful run of each query are kept when a later run fails, and failures are counted in
+// cartographer_scrape_errors_total.
+type MetricsCollector struct {
+	c        *Cartographer
+	interval time.Duration
+	now      func() time.Time
+
+	mu             sync.RWMutex
+	families       map[string][]metricFamily
+	scrapeErrors   map[string]int
+	lastSuccess    time.Time
+	scrapeDuration time.Duration
+}
+
+// NewMetricsCollector creates a collector that queries the organization every interval once Run is called.
+func NewMetricsCollector(c *Cartographer, interval time.Duration) *MetricsCollector {
+	return &MetricsCollector{
+		c:            c,
+		interval:     interval,
+		now:          time.Now,
+		families:     make(map[string][]metricFamily),
+		scrapeErrors: make(map[string]int),
+	}
+}
+
+// Run collects metrics immediately and then every interval until the context is cancelled. Errors are recorded in
+// the scrape error counter rather than stopping the collector.
+func (m *MetricsCollector) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		_ = m.Collect()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Collect runs the workspace, module and provider queries once and updates the metrics. It returns the first error
+// encountered; queries that succeeded are still updated.
+func (m *MetricsCollector) Collect() error {
+	start := m.now()
+	var firstErr error
+	record := func(query string, families []metricFamily, err error) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if err != nil {
+			m.scrapeErrors[query]++
+			if firstErr == nil {
+				firstErr = fmt.Errorf("collect %s: %w", query, err)
+			}
+			return
+		}
+		m.families[query] = families
+	}
+
+	workspaces, err := m.c.Workspaces(nil)
+	record("workspaces", workspaceMetrics(workspaces), err)
+
+	modules, err := m.c.Modules(nil)
+	record("modules", usageMetrics("module", len(modules), func(i int) (string, string, []string, int) {
+		return modules[i].Source, modules[i].Version, modules[i].WorkspaceNames(), modules[i].WorkspaceCount
+	}), err)
+
+	providers, err := m.c.Providers(nil)
+	record("providers", usageMetrics("provider", len(providers), func(i int) (string, string, []string, int) {
+		return providers[i].Source, providers[i].Version, providers[i].WorkspaceNames(), providers[i].WorkspaceCount
+	}), err)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.scrapeDuration = m.now().Sub(start)
+	if firstErr == nil {
+		m.lastSuccess = m.now()
+	}
+	return firstErr
+}
+
+// workspaceMetrics builds the workspace gauges: workspaces per project and Terraform version, and drifted and
+// failing workspaces per project.
+func workspaceMetrics(workspaces []Workspace) []metricFamily {
+	byProject := make(map[string]float64)
+	drifted := make(map[string]float64)
+	checksFailed := make(map[string]float64)
+	byVersion := make(map[string]float64)
+	for _, w := range workspaces {
+		byProject[w.ProjectName]++
+		byVersion[w.WorkspaceTerraformVersion]++
+		// Projects without drifted or failing workspaces are reported as zero so that dashboards show them.
+		drifted[w.ProjectName] += boolValue(w.Drifted)
+		checksFailed[w.ProjectName] += boolValue(w.ChecksFailed > 0 || w.ChecksErrored > 0)
+	}
+
+	return []metricFamily{
+		labelledGauge("workspaces", "Number of workspaces per project.", "project", byProject),
+		labelledGauge("workspaces_by_terraform_version", "Number of workspaces per Terraform version.", "version", byVersion),
+		labelledGauge("drifted_workspaces", "Number of drifted workspaces per project.", "project", drifted),
+		labelledGauge("checks_failed_workspaces", "Number of workspaces with failed or errored health checks per project.", "project", checksFailed),
+	}
+}
+
+// boolValue returns 1 for true and 0 for false.
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// usageMetrics builds the gauge of workspaces using each version of a module or provider. The Explorer API can return
+// several rows for the same source and version, for example one per module name, so rows are merged and each
+// workspace is counted once. Rows without workspace names fall back to their workspace count.
+func usageMetrics(kind string, n int, row func(i int) (source, version string, workspaces []string, count int)) []metricFamily {
+	type usage struct {
+		workspaces map[string]bool
+		unnamed    int
+	}
+	usages := make(map[[2]string]*usage)
+	for i := 0; i < n; i++ {
+		source, version, workspaces, count := row(i)
+		key := [2]string{source, version}
+		u, ok := usages[key]
+		if !ok {
+			u = &usage{workspaces: make(map[string]bool)}
+			usages[key] = u
+		}
+		if len(workspaces) == 0 {
+			u.unnamed += count
+		}
+		for _, w := range workspaces {
+			u.workspaces[w] = true
+		}
+	}
+
+	family := metricFamily{
+		name: metricsNamespace + kind + "_workspaces",
+		help: fmt.Sprintf("Number of workspaces using each version of a %s.", kind),
+		kind: "gauge",
+	}
+	for key, u := range usages {
+		family.samples = append(family.samples, metricSample{
+			labels: [][2]string{{"source", key[0]}, {"version", key[1]}},
+			value:  float64(len(u.workspaces) + u.unnamed),
+		})
+	}
+	sortSamples(family.samples)
+	return []metricFamily{family}
+}
+
+// labelledGauge builds a gauge with a single label from a map of label values to values.
+func labelledGauge(name, help, label string, values map[string]float64) metricFamily {
+	family := metricFamily{name: metricsNamespace + name, help: help, kind: "gauge"}
+	for value, v := range values {
+		family.samples = append(family.samples, metricSample{labels: [][2]string{{label, value}}, value: v})
+	}
+	sortSamples(family.samples)
+	return family
+}
+
+// sortSamples sorts samples by their label values so that output is stable between scrapes.
+func sortSamples(samples []metricSample) {
+	sort.Slice(samples, func(i, j int) bool {
+		a, b := samples[i].labels, samples[j].labels
+		for k := 0; k < len(a) && k < len(b); k++ {
+			if a[k][1] != b[k][1] {
+				return a[k][1] < b[k][1]
+			}
+		}
+		return len(a) < len(b)
+	})
+}
+
+// ServeHTTP writes the current metrics in the Prometheus text exposition format.
+func (m *MetricsCollector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = w.Write([]byte(m.Expose()))
+}
+
+// Expose renders the current metrics in the Prometheus text exposition format.
+func (m *MetricsCollector) Expose() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var families []metricFamily
+	for _, query := range metricsQueries {
+		families = append(families, m.families[query]...)
+	}
+
+	scrapeErrors := metricFamily{
+		name: metricsNamespace + "scrape_errors_total",
+		help: "Number of failed queries per query type.",
+		kind: "counter",
+	}
+	for _, query := range metricsQueries {
+		scrapeErrors.samples = append(scrapeErrors.samples, metricSample{
+			labels: [][2]string{{"query", query}},
+			value:  float64(m.scrapeErrors[query]),
+		})
+	}
+	families = append(families, scrapeErrors, metricFamily{
+		name:    metricsNamespace + "scrape_duration_seconds",
+		help:    "Duration of the last collection in seconds.",
+		kind:    "gauge",
+		samples: []metricSample{{value: m.scrapeDuration.Seconds()}},
+	})
+	if !m.lastSuccess.IsZero() {
+		families = append(families, metricFamily{
+			name:    metricsNamespace + "last_success_timestamp_seconds",
+			help:    "Unix time of the last collection in which every query succeeded.",
+			kind:    "gauge",
+			samples: []metricSample{{value: float64(m.lastSuccess.Unix())}},
+		})
+	}
+
+	var b strings.Builder
+	for _, f := range families {
+		fmt.Fprintf(&b, "# HELP %s %s\n", f.name, f.help)
+		fmt.Fprintf(&b, "# TYPE %s %s\n", f.name, f.kind)
+		for _, s := range f.samples {
+			b.WriteString(f.name)
+			if len(s.labels) > 0 {
+				var labels []string
+				for _, l := range s.labels {
+					labels = append(labels, l[0]+"=\""+labelEscaper.Replace(l[1])+"\"")
+				}
+				b.WriteString("{" + strings.Join(labels, ",") + "}")
+			}
+			b.WriteString(" " + strconv.FormatFloat(s.value, 'g', -1, 64) + "\n")
+		}
+	}
+	return b.String()
+}
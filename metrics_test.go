@@ -0,0 +1,83 @@
+package cartographer
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMetricsCollector(t *testing.T) {
+	page := `"links": {"self": "test", "first": "test", "last": "test", "prev": null, "next": null},
+		"meta": {"pagination": {"current-page": 1, "page-size": 100, "next-page": null, "prev-page": null, "total-pages": 1, "total-count": 1}}`
+	responses := map[string]string{
+		"workspaces": `{"data": [
+			{"attributes": {"workspace-name": "a", "project-name": "net\"work", "workspace-terraform-version": "1.6.0", "drifted": true, "workspace-updated-at": "2024-01-01T00:00:00Z"}, "id": "1", "type": "t"},
+			{"attributes": {"workspace-name": "b", "project-name": "apps", "workspace-terraform-version": "1.6.0", "checks-failed": 2, "workspace-updated-at": "2024-01-01T00:00:00Z"}, "id": "2", "type": "t"},
+			{"attributes": {"workspace-name": "c", "project-name": "apps", "workspace-terraform-version": "1.6.0", "checks-errored": 1, "workspace-updated-at": "2024-01-01T00:00:00Z"}, "id": "5", "type": "t"}
+		], ` + page + `}`,
+		// Both rows use the same source and version under different module names and share workspace a.
+		"modules": `{"data": [
+			{"attributes": {"name": "vpc", "source": "app.terraform.io/test/vpc/aws", "version": "1.0.0", "workspace-count": 2, "workspaces": "a,b"}, "id": "3", "type": "t"},
+			{"attributes": {"name": "network", "source": "app.terraform.io/test/vpc/aws", "version": "1.0.0", "workspace-count": 2, "workspaces": "a,c"}, "id": "6", "type": "t"}
+		], ` + page + `}`,
+		"providers": `{"data": [{"attributes": {"source": "hashicorp/aws", "version": "5.0.0", "workspace-count": 1}, "id": "4", "type": "t"}], ` + page + `}`,
+	}
+
+	failProviders := false
+	mockClient := &MockClient{
+		MockDo: func(req *http.Request) (*http.Response, error) {
+			query := req.URL.Query().Get("type")
+			if query == "providers" && failProviders {
+				return &http.Response{StatusCode: 500, Body: io.NopCloser(strings.NewReader(""))}, nil
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(responses[query])),
+			}, nil
+		},
+	}
+
+	collector := NewMetricsCollector(NewCartographerWithClient("test", "test", mockClient), time.Minute)
+	collector.now = func() time.Time { return time.Unix(1700000000, 0) }
+	if err := collector.Collect(); err != nil {
+		t.Fatalf("Collect() returned an error: %v", err)
+	}
+
+	failProviders = true
+	if err := collector.Collect(); err == nil {
+		t.Errorf("Collect() with a failing query expected an error")
+	}
+
+	recorder := httptest.NewRecorder()
+	collector.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if got := recorder.Header().Get("Content-Type"); !strings.HasPrefix(got, "text/plain; version=0.0.4") {
+		t.Errorf("ServeHTTP() returned content type %q", got)
+	}
+
+	body := recorder.Body.String()
+	for _, line := range []string{
+		"# TYPE cartographer_workspaces gauge",
+		`cartographer_workspaces{project="apps"} 2`,
+		`cartographer_workspaces_by_terraform_version{version="1.6.0"} 3`,
+		`cartographer_drifted_workspaces{project="apps"} 0`,
+		`cartographer_drifted_workspaces{project="net\"work"} 1`,
+		`cartographer_checks_failed_workspaces{project="apps"} 2`,
+		`cartographer_module_workspaces{source="app.terraform.io/test/vpc/aws",version="1.0.0"} 3`,
+		// The provider metrics from the first collection are kept after the second one fails.
+		`cartographer_provider_workspaces{source="hashicorp/aws",version="5.0.0"} 1`,
+		"# TYPE cartographer_scrape_errors_total counter",
+		`cartographer_scrape_errors_total{query="providers"} 1`,
+		`cartographer_scrape_errors_total{query="workspaces"} 0`,
+		"cartographer_last_success_timestamp_seconds 1.7e+09",
+	} {
+		if !strings.Contains(body, line+"\n") {
+			t.Errorf("ServeHTTP() output is missing %q:\n%s", line, body)
+		}
+	}
+	if n := strings.Count(body, "cartographer_module_workspaces{"); n != 1 {
+		t.Errorf("ServeHTTP() wrote %d module samples, expected rows with the same source and version to be merged", n)
+	}
+}
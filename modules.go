@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 )
 
 const (
@@ -19,7 +20,7 @@ const (
 type ModuleFilterType int
 
 func (m ModuleFilterType) String() string {
-	return [...]string{"name", "source", "version", "workspace-count", "workspaces"}[m]
+	return [...]string{"name", "source", "version", "registry-type", "workspace-count", "workspaces"}[m]
 }
 
 type ModuleFilter struct {
@@ -31,11 +32,18 @@ type ModuleFilter struct {
 // Modules Retrieve a list of modules across all workspaces in an organization. It takes a slice of ModuleFilter and
 // returns a slice of Module. If the request fails, it returns an error.
 func (c *Cartographer) Modules(filters []ModuleFilter) ([]Module, error) {
+	modules, _, err := c.ModulesWithMeta(filters)
+	return modules, err
+}
+
+// ModulesWithMeta behaves like Modules but also returns the links and pagination metadata of the query.
+func (c *Cartographer) ModulesWithMeta(filters []ModuleFilter) ([]Module, *QueryMeta, error) {
 	var modules []Module
+	var meta *QueryMeta
 
 	baseUrl, err := buildExplorerUrl(c.orgName)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	q := url.Values{}
@@ -51,7 +59,7 @@ func (c *Cartographer) Modules(filters []ModuleFilter) ([]Module, error) {
 
 	req, err := http.NewRequest("GET", baseUrl.String(), nil)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	req.Header.Set("Authorization", "Bearer "+c.token)
@@ -59,21 +67,38 @@ func (c *Cartographer) Modules(filters []ModuleFilter) ([]Module, error) {
 	for {
 		res, err := c.client.Do(req)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		if err := checkStatusCode(res); err != nil {
-			return nil, err
+			res.Body.Close()
+			return nil, nil, err
 		}
 
 		var apiResponse modulesApiResponse
-		if err = json.NewDecoder(res.Body).Decode(&apiResponse); err != nil {
-			return nil, err
+		err = json.NewDecoder(res.Body).Decode(&apiResponse)
+		res.Body.Close()
+		if err != nil {
+			return nil, nil, err
 		}
 
 		for _, item := range apiResponse.Data {
-			modules = append(modules, item.Attributes)
+			modules = append(modules, Module{
+				Id:             item.Id,
+				Type:           item.Type,
+				Name:           item.Attributes.Name,
+				Source:         item.Attributes.Source,
+				Version:        item.Attributes.Version,
+				RegistryType:   item.Attributes.RegistryType,
+				WorkspaceCount: item.Attributes.WorkspaceCount,
+				Workspaces:     item.Attributes.Workspaces,
+			})
+		}
+
+		if meta == nil {
+			meta = newQueryMeta(apiResponse.Links, apiResponse.Meta)
 		}
+		meta.PagesFetched++
 
 		if apiResponse.Meta.Pagination.NextPage == nil {
 			break
@@ -85,18 +110,19 @@ func (c *Cartographer) Modules(filters []ModuleFilter) ([]Module, error) {
 
 		req.URL, err = url.Parse(apiResponse.Links.Next.(string))
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
-		res.Body.Close()
 
 		preventRateLimiting(apiResponse.Meta.Pagination.TotalPages)
 	}
 
-	return modules, nil
+	return modules, meta, nil
 }
 
 // Module represents a module in Terraform Cloud
 type Module struct {
+	Id             string `json:"id"`
+	Type           string `json:"type"`
 	Name           string `json:"name"`
 	Source         string `json:"source"`
 	Version        string `json:"version"`
@@ -105,6 +131,23 @@ type Module struct {
 	Workspaces     string `json:"workspaces"`
 }
 
+// WorkspaceNames returns the names of the workspaces using the module.
+func (m Module) WorkspaceNames() []string {
+	return splitList(m.Workspaces)
+}
+
+// normalizeModuleSource normalises a module source address so that the same module referenced in different ways
+// compares equal. The source is lowercased, the implicit public registry hostname is removed and any subdirectory
+// after "//" is dropped so that submodules resolve to their parent module.
+func normalizeModuleSource(source string) string {
+	s := strings.ToLower(strings.TrimSpace(source))
+	s = strings.TrimPrefix(s, "registry.terraform.io/")
+	if i := strings.Index(s, "//"); i >= 0 {
+		s = s[:i]
+	}
+	return s
+}
+
 // modulesApiResponse is the response from the Terraform Cloud API
 type modulesApiResponse struct {
 	Data []struct {
@@ -119,21 +162,6 @@ type modulesApiResponse struct {
 		Id   string `json:"id"`
 		Type string `json:"type"`
 	} `json:"data"`
-	Links struct {
-		Self  string      `json:"self"`
-		First string      `json:"first"`
-		Last  string      `json:"last"`
-		Prev  interface{} `json:"prev"`
-		Next  interface{} `json:"next"`
-	} `json:"links"`
-	Meta struct {
-		Pagination struct {
-			CurrentPage int         `json:"current-page"`
-			PageSize    int         `json:"page-size"`
-			NextPage    interface{} `json:"next-page"`
-			PrevPage    interface{} `json:"prev-page"`
-			TotalPages  int         `json:"total-pages"`
-			TotalCount  int         `json:"total-count"`
-		} `json:"pagination"`
-	} `json:"meta"`
+	Links apiLinks `json:"links"`
+	Meta  apiMeta  `json:"meta"`
 }
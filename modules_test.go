@@ -67,6 +67,12 @@ func TestModules(t *testing.T) {
 	}
 
 	module := modules[0]
+	if module.Id != "test" {
+		t.Errorf("Modules() returned module with id %v, expected 'test'", module.Id)
+	}
+	if module.Type != "test" {
+		t.Errorf("Modules() returned module with type %v, expected 'test'", module.Type)
+	}
 	if module.Name != "testname" {
 		t.Errorf("Modules() returned module with name %v, expected 'test'", module.Name)
 	}
@@ -86,3 +92,66 @@ func TestModules(t *testing.T) {
 		t.Errorf("Modules() returned module with workspaces %v, expected 'test'", module.Workspaces)
 	}
 }
+
+func TestModulesWithMeta(t *testing.T) {
+	pages := []string{
+		`{
+			"data": [{"attributes": {"name": "one"}, "id": "row-1", "type": "explorer-module-row"}],
+			"links": {"self": "https://example.com/self", "first": "https://example.com/first", "last": "https://example.com/last", "prev": null, "next": "https://example.com/next"},
+			"meta": {"pagination": {"current-page": 1, "page-size": 1, "next-page": 2, "prev-page": null, "total-pages": 2, "total-count": 2}}
+		}`,
+		`{
+			"data": [{"attributes": {"name": "two"}, "id": "row-2", "type": "explorer-module-row"}],
+			"links": {"self": "https://example.com/next", "first": "https://example.com/first", "last": "https://example.com/last", "prev": "https://example.com/self", "next": null},
+			"meta": {"pagination": {"current-page": 2, "page-size": 1, "next-page": null, "prev-page": 1, "total-pages": 2, "total-count": 2}}
+		}`,
+	}
+
+	page := 0
+	mockClient := &MockClient{
+		MockDo: func(req *http.Request) (*http.Response, error) {
+			body := pages[page]
+			page++
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(body)),
+			}, nil
+		},
+	}
+
+	c := &Cartographer{
+		client:  mockClient,
+		orgName: "test",
+		token:   "test",
+	}
+
+	modules, meta, err := c.ModulesWithMeta([]ModuleFilter{})
+	if err != nil {
+		t.Fatalf("ModulesWithMeta() returned an error: %v", err)
+	}
+
+	if len(modules) != 2 {
+		t.Fatalf("ModulesWithMeta() returned %v modules, expected 2", len(modules))
+	}
+	if modules[1].Id != "row-2" {
+		t.Errorf("ModulesWithMeta() returned module with id %v, expected 'row-2'", modules[1].Id)
+	}
+	if meta.Self != "https://example.com/self" {
+		t.Errorf("ModulesWithMeta() returned self link %v, expected 'https://example.com/self'", meta.Self)
+	}
+	if meta.TotalCount != 2 {
+		t.Errorf("ModulesWithMeta() returned total count %v, expected 2", meta.TotalCount)
+	}
+	if meta.PagesFetched != 2 {
+		t.Errorf("ModulesWithMeta() returned pages fetched %v, expected 2", meta.PagesFetched)
+	}
+}
+
+func TestModuleFilterTypeString(t *testing.T) {
+	if ModuleRegistryType.String() != "registry-type" {
+		t.Errorf("ModuleRegistryType.String() returned %v, expected 'registry-type'", ModuleRegistryType.String())
+	}
+	if ModuleInWorkspaces.String() != "workspaces" {
+		t.Errorf("ModuleInWorkspaces.String() returned %v, expected 'workspaces'", ModuleInWorkspaces.String())
+	}
+}
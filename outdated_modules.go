@@ -0,0 +1,179 @@
+package cartographer
+
+import (
+	"sort"
+)
+
+// OutdatedModules builds an OutdatedModuleReport for the organization by joining the private registry modules with the
+// module usage reported by the Explorer.
+func (c *Cartographer) OutdatedModules() (*OutdatedModuleReport, error) {
+	registryModules, err := c.PrivateRegistryModules()
+	if err != nil {
+		return nil, err
+	}
+
+	modules, err := c.Modules(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewOutdatedModuleReport(registryModules, modules), nil
+}
+
+// NewOutdatedModuleReport joins private registry modules with Explorer module usage by source. Every registry module
+// is included in the report, sorted by source, with the versions in use sorted from newest to oldest. Explorer modules
+// that do not come from the registry are ignored.
+func NewOutdatedModuleReport(registryModules []PrivateRegistryModule, modules []Module) *OutdatedModuleReport {
+	usage := make(map[string][]Module)
+	for _, m := range modules {
+		source := normalizeModuleSource(m.Source)
+		usage[source] = append(usage[source], m)
+	}
+
+	report := &OutdatedModuleReport{}
+	for _, rm := range registryModules {
+		published := publishedVersions(rm.Versions)
+
+		outdated := OutdatedModule{
+			Source:        rm.Source(),
+			Name:          rm.Name,
+			Namespace:     rm.Namespace,
+			Provider:      rm.Provider,
+			LatestVersion: rm.LatestVersion,
+		}
+
+		byVersion := make(map[string]*ModuleVersionUsage)
+		for _, m := range usage[normalizeModuleSource(rm.Source())] {
+			v, ok := byVersion[m.Version]
+			if !ok {
+				v = &ModuleVersionUsage{Version: m.Version}
+				v.MajorsBehind, v.MinorsBehind, v.PatchesBehind = versionsBehind(m.Version, published)
+				byVersion[m.Version] = v
+			}
+			// Root module and submodule rows, or rows for the same source under different names, can share workspaces.
+			for _, w := range m.WorkspaceNames() {
+				v.Workspaces = appendUnique(v.Workspaces, w)
+			}
+		}
+
+		for _, v := range byVersion {
+			sort.Strings(v.Workspaces)
+			outdated.Versions = append(outdated.Versions, *v)
+		}
+		sort.Slice(outdated.Versions, func(i, j int) bool {
+			return compareVersions(outdated.Versions[i].Version, outdated.Versions[j].Version) > 0
+		})
+
+		report.Modules = append(report.Modules, outdated)
+	}
+
+	sort.SliceStable(report.Modules, func(i, j int) bool {
+		return report.Modules[i].Source < report.Modules[j].Source
+	})
+
+	return report
+}
+
+// OutdatedModuleReport lists, for every private registry module, the versions in use across the organization and how
+// far behind the latest published version each of them is.
+type OutdatedModuleReport struct {
+	Modules []OutdatedModule `json:"modules"`
+}
+
+// Outdated returns the modules with at least one workspace on a version older than the latest published version.
+func (r *OutdatedModuleReport) Outdated() []OutdatedModule {
+	var modules []OutdatedModule
+	for _, m := range r.Modules {
+		if len(m.OutdatedWorkspaces()) > 0 {
+			modules = append(modules, m)
+		}
+	}
+	return modules
+}
+
+// OutdatedModule represents a private registry module and the versions of it that are in use.
+type OutdatedModule struct {
+	Source        string               `json:"source"`
+	Name          string               `json:"name"`
+	Namespace     string               `json:"namespace"`
+	Provider      string               `json:"provider"`
+	LatestVersion string               `json:"latest_version"`
+	Versions      []ModuleVersionUsage `json:"versions"`
+}
+
+// OutdatedWorkspaces returns the names of the workspaces using a version older than the latest published version. A
+// workspace using several outdated versions is listed once.
+func (m OutdatedModule) OutdatedWorkspaces() []string {
+	var workspaces []string
+	for _, v := range m.Versions {
+		if !v.Outdated() {
+			continue
+		}
+		for _, w := range v.Workspaces {
+			workspaces = appendUnique(workspaces, w)
+		}
+	}
+	sort.Strings(workspaces)
+	return workspaces
+}
+
+// ModuleVersionUsage represents a single version of a module and the workspaces using it. The behind counts are the
+// number of newer published major lines, minor lines and patch releases within the version's own minor line, so a
+// workspace on 1.2.0 when 1.2.1, 1.3.0 and 2.0.0 are published is 1 major, 2 minor and 1 patch version behind.
+// Prereleases are not counted.
+type ModuleVersionUsage struct {
+	Version       string   `json:"version"`
+	Workspaces    []string `json:"workspaces"`
+	MajorsBehind  int      `json:"majors_behind"`
+	MinorsBehind  int      `json:"minors_behind"`
+	PatchesBehind int      `json:"patches_behind"`
+}
+
+// Outdated reports whether a newer version of the module has been published.
+func (v ModuleVersionUsage) Outdated() bool {
+	return v.MajorsBehind > 0 || v.MinorsBehind > 0 || v.PatchesBehind > 0
+}
+
+// publishedVersions returns the parsed stable versions with an "ok" status.
+func publishedVersions(versions []PrivateRegistryModuleVersion) []semver {
+	var published []semver
+	for _, v := range versions {
+		if v.Status != "ok" {
+			continue
+		}
+		sv, err := parseSemver(v.Version)
+		if err != nil || sv.Prerelease != "" {
+			continue
+		}
+		published = append(published, sv)
+	}
+	return published
+}
+
+// versionsBehind counts the published major lines and minor lines that are newer than version, and the newer patch
+// releases of its own major.minor line. A version that cannot be parsed is reported as zero behind.
+func versionsBehind(version string, published []semver) (majors, minors, patches int) {
+	current, err := parseSemver(version)
+	if err != nil {
+		return 0, 0, 0
+	}
+
+	majorLines := make(map[int]bool)
+	minorLines := make(map[[2]int]bool)
+	for _, v := range published {
+		if v.compare(current) <= 0 {
+			continue
+		}
+		if v.Major == current.Major && v.Minor == current.Minor {
+			patches++
+		}
+		if v.Major > current.Major {
+			majorLines[v.Major] = true
+		}
+		if v.Major > current.Major || v.Minor > current.Minor {
+			minorLines[[2]int{v.Major, v.Minor}] = true
+		}
+	}
+
+	return len(majorLines), len(minorLines), patches
+}
@@ -0,0 +1,88 @@
+package cartographer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewOutdatedModuleReport(t *testing.T) {
+	registryModules := []PrivateRegistryModule{
+		{
+			Name:         "vpc",
+			Namespace:    "myOrgName",
+			Provider:     "aws",
+			RegistryName: "private",
+			Versions: []PrivateRegistryModuleVersion{
+				{Version: "2.0.0", Status: "ok"},
+				{Version: "1.3.0", Status: "ok"},
+				{Version: "1.2.1", Status: "ok"},
+				{Version: "1.2.0", Status: "ok"},
+				{Version: "3.0.0-beta.1", Status: "ok"},
+				{Version: "2.1.0", Status: "errored"},
+			},
+			LatestVersion: "2.0.0",
+		},
+		{
+			Name:          "iam",
+			Namespace:     "myOrgName",
+			Provider:      "aws",
+			RegistryName:  "private",
+			Versions:      []PrivateRegistryModuleVersion{{Version: "0.1.0", Status: "ok"}},
+			LatestVersion: "0.1.0",
+		},
+	}
+
+	modules := []Module{
+		{Name: "vpc", Source: "app.terraform.io/myOrgName/vpc/aws", Version: "1.2.0", Workspaces: "ws-b,ws-a"},
+		{Name: "subnets", Source: "app.terraform.io/myOrgName/vpc/aws//modules/subnets", Version: "1.2.0", Workspaces: "ws-c,ws-a"},
+		{Name: "network", Source: "app.terraform.io/myOrgName/vpc/aws", Version: "1.3.0", Workspaces: "ws-a"},
+		{Name: "vpc", Source: "app.terraform.io/myOrgName/vpc/aws", Version: "2.0.0", Workspaces: "ws-d"},
+		{Name: "local", Source: "./modules/local", Version: "", Workspaces: "ws-a"},
+	}
+
+	report := NewOutdatedModuleReport(registryModules, modules)
+
+	if len(report.Modules) != 2 {
+		t.Fatalf("NewOutdatedModuleReport() returned %v modules, expected 2", len(report.Modules))
+	}
+
+	iam := report.Modules[0]
+	if iam.Source != "app.terraform.io/myOrgName/iam/aws" || len(iam.Versions) != 0 {
+		t.Errorf("NewOutdatedModuleReport() returned %+v, expected unused iam module first", iam)
+	}
+
+	vpc := report.Modules[1]
+	if len(vpc.Versions) != 3 {
+		t.Fatalf("NewOutdatedModuleReport() returned %v vpc versions, expected 3", len(vpc.Versions))
+	}
+
+	latest := vpc.Versions[0]
+	if latest.Version != "2.0.0" || latest.Outdated() {
+		t.Errorf("NewOutdatedModuleReport() returned %+v, expected 2.0.0 to be up to date", latest)
+	}
+
+	old := vpc.Versions[2]
+	if old.Version != "1.2.0" {
+		t.Fatalf("NewOutdatedModuleReport() returned version %v, expected '1.2.0'", old.Version)
+	}
+	// ws-a is listed by both the root module and submodule rows but is only counted once.
+	if !reflect.DeepEqual(old.Workspaces, []string{"ws-a", "ws-b", "ws-c"}) {
+		t.Errorf("NewOutdatedModuleReport() returned workspaces %v", old.Workspaces)
+	}
+	if old.MajorsBehind != 1 || old.MinorsBehind != 2 || old.PatchesBehind != 1 {
+		t.Errorf("NewOutdatedModuleReport() returned %d/%d/%d behind, expected 1/2/1", old.MajorsBehind, old.MinorsBehind, old.PatchesBehind)
+	}
+
+	// 1.3.0 is the newest release of its minor line, so it is behind on major and minor versions only.
+	if onlyMajor := vpc.Versions[1]; onlyMajor.MajorsBehind != 1 || onlyMajor.MinorsBehind != 1 || onlyMajor.PatchesBehind != 0 || !onlyMajor.Outdated() {
+		t.Errorf("NewOutdatedModuleReport() returned %+v, expected 1/1/0 behind and outdated", onlyMajor)
+	}
+
+	outdated := report.Outdated()
+	if len(outdated) != 1 || outdated[0].Name != "vpc" {
+		t.Errorf("Outdated() returned %+v, expected only vpc", outdated)
+	}
+	if !reflect.DeepEqual(vpc.OutdatedWorkspaces(), []string{"ws-a", "ws-b", "ws-c"}) {
+		t.Errorf("OutdatedWorkspaces() returned %v", vpc.OutdatedWorkspaces())
+	}
+}
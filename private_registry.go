@@ -2,15 +2,51 @@ package cartographer
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/url"
+	"sort"
 	"time"
 )
 
-// PrivateRegistryModules retrieves a list of all modules in the given organization's private registry. It only returns
-// the latest version of the module. This is currently used by indexing to 0 which as of now is the latest version.
-// No comparison is done to check if the version is the latest.
+const (
+	AnyRegistry RegistryName = iota
+	PrivateRegistry
+	PublicRegistry
+)
+
+// RegistryName identifies whether a registry module is hosted privately or is a public module added to the
+// organization's registry.
+type RegistryName int
+
+func (r RegistryName) String() string {
+	return [...]string{"", "private", "public"}[r]
+}
+
+// RegistryModuleOptions narrows down the modules returned by SearchPrivateRegistryModules. Empty fields are ignored.
+type RegistryModuleOptions struct {
+	// Search is a free-text query matched against module names, namespaces and providers.
+	Search    string
+	Provider  string
+	Namespace string
+	Registry  RegistryName
+	// OrganizationName only returns modules published by the named organization, such as modules shared with the
+	// organization from another one.
+	OrganizationName string
+}
+
+// PrivateRegistryModules retrieves a list of all modules in the given organization's private registry. Every version of
+// each module is returned along with its status, and LatestVersion is the highest successfully published version.
+// Modules without any published version are still returned with an empty LatestVersion; use Published to tell them
+// apart. If the API responds with a non-2xx status code, an *APIError is returned.
 func (c *Cartographer) PrivateRegistryModules() ([]PrivateRegistryModule, error) {
+	return c.SearchPrivateRegistryModules(RegistryModuleOptions{})
+}
+
+// SearchPrivateRegistryModules retrieves the modules in the given organization's registry that match the options.
+// Search, Provider, Registry and OrganizationName are applied by the API, while Namespace is matched against the results as the API
+// offers no namespace filter. The returned modules are populated the same way as PrivateRegistryModules.
+func (c *Cartographer) SearchPrivateRegistryModules(opts RegistryModuleOptions) ([]PrivateRegistryModule, error) {
 	var modules []PrivateRegistryModule
 
 	baseUrl, err := buildRegistryUrl(c.orgName)
@@ -21,6 +57,19 @@ func (c *Cartographer) PrivateRegistryModules() ([]PrivateRegistryModule, error)
 	q := url.Values{}
 	q.Add("page[size]", "100")
 
+	if opts.Search != "" {
+		q.Add("q", opts.Search)
+	}
+	if opts.Provider != "" {
+		q.Add("filter[provider]", opts.Provider)
+	}
+	if opts.Registry != AnyRegistry {
+		q.Add("filter[registry_name]", opts.Registry.String())
+	}
+	if opts.OrganizationName != "" {
+		q.Add("filter[organization_name]", opts.OrganizationName)
+	}
+
 	baseUrl.RawQuery = q.Encode()
 
 	req, err := http.NewRequest("GET", baseUrl.String(), nil)
@@ -36,22 +85,23 @@ func (c *Cartographer) PrivateRegistryModules() ([]PrivateRegistryModule, error)
 			return nil, err
 		}
 
+		if err := checkStatusCode(res); err != nil {
+			res.Body.Close()
+			return nil, err
+		}
+
 		var apiResponse privateRegistryApiResponse
 		err = json.NewDecoder(res.Body).Decode(&apiResponse)
+		res.Body.Close()
 		if err != nil {
 			return nil, err
 		}
 
 		for _, registry := range apiResponse.Data {
-			modules = append(modules, PrivateRegistryModule{
-				Id:            registry.Id,
-				Type:          registry.Type,
-				Name:          registry.Attributes.Name,
-				Status:        registry.Attributes.Status,
-				LatestVersion: registry.Attributes.VersionStatuses[0].Version,
-				UpdatedAt:     registry.Attributes.UpdatedAt,
-				CreatedAt:     registry.Attributes.CreatedAt,
-			})
+			if opts.Namespace != "" && registry.Attributes.Namespace != opts.Namespace {
+				continue
+			}
+			modules = append(modules, newPrivateRegistryModule(registry.Id, registry.Type, registry.Attributes))
 		}
 
 		if apiResponse.Meta.Pagination.NextPage == nil {
@@ -66,7 +116,6 @@ func (c *Cartographer) PrivateRegistryModules() ([]PrivateRegistryModule, error)
 		if err != nil {
 			return nil, err
 		}
-		res.Body.Close()
 
 		preventRateLimiting(apiResponse.Meta.Pagination.TotalPages)
 	}
@@ -74,51 +123,138 @@ func (c *Cartographer) PrivateRegistryModules() ([]PrivateRegistryModule, error)
 	return modules, nil
 }
 
+// PrivateRegistryModule represents a module published to an organization's private registry.
 type PrivateRegistryModule struct {
-	Id            string    `json:"id"`
-	Type          string    `json:"type"`
-	Name          string    `json:"name"`
-	Status        string    `json:"status"`
-	LatestVersion string    `json:"latest_version"`
-	UpdatedAt     time.Time `json:"updated_at"`
-	CreatedAt     time.Time `json:"created_at"`
+	Id                  string                         `json:"id"`
+	Type                string                         `json:"type"`
+	Name                string                         `json:"name"`
+	Namespace           string                         `json:"namespace"`
+	Provider            string                         `json:"provider"`
+	RegistryName        string                         `json:"registry_name"`
+	Status              string                         `json:"status"`
+	NoCode              bool                           `json:"no_code"`
+	PublishingMechanism string                         `json:"publishing_mechanism"`
+	VcsRepo             *PrivateRegistryVcsRepo        `json:"vcs_repo"`
+	Versions            []PrivateRegistryModuleVersion `json:"versions"`
+	LatestVersion       string                         `json:"latest_version"`
+	UpdatedAt           time.Time                      `json:"updated_at"`
+	CreatedAt           time.Time                      `json:"created_at"`
+}
+
+// Published reports whether at least one version of the module has been successfully published.
+func (m PrivateRegistryModule) Published() bool {
+	return m.LatestVersion != ""
+}
+
+// Source returns the source address Terraform configurations use to call the module.
+func (m PrivateRegistryModule) Source() string {
+	if m.RegistryName == "public" {
+		return fmt.Sprintf("%s/%s/%s", m.Namespace, m.Name, m.Provider)
+	}
+	return fmt.Sprintf("app.terraform.io/%s/%s/%s", m.Namespace, m.Name, m.Provider)
+}
+
+// PrivateRegistryModuleVersion represents a single version of a private registry module and its publishing status.
+type PrivateRegistryModuleVersion struct {
+	Version string `json:"version"`
+	Status  string `json:"status"`
+}
+
+// PrivateRegistryVcsRepo represents the VCS repository a private registry module is published from.
+type PrivateRegistryVcsRepo struct {
+	Branch                  string `json:"branch"`
+	IngressSubmodules       bool   `json:"ingress_submodules"`
+	TagsRegex               string `json:"tags_regex"`
+	Identifier              string `json:"identifier"`
+	DisplayIdentifier       string `json:"display_identifier"`
+	GithubAppInstallationId string `json:"github_app_installation_id"`
+	RepositoryHttpUrl       string `json:"repository_http_url"`
+	ServiceProvider         string `json:"service_provider"`
+	Tags                    bool   `json:"tags"`
+}
+
+// newPrivateRegistryModule converts the attributes of a registry-modules API item into a PrivateRegistryModule. The
+// versions are sorted from newest to oldest.
+func newPrivateRegistryModule(id string, typ string, attrs privateRegistryModuleAttributes) PrivateRegistryModule {
+	module := PrivateRegistryModule{
+		Id:                  id,
+		Type:                typ,
+		Name:                attrs.Name,
+		Namespace:           attrs.Namespace,
+		Provider:            attrs.Provider,
+		RegistryName:        attrs.RegistryName,
+		Status:              attrs.Status,
+		NoCode:              attrs.NoCode,
+		PublishingMechanism: attrs.PublishingMechanism,
+		UpdatedAt:           attrs.UpdatedAt,
+		CreatedAt:           attrs.CreatedAt,
+	}
+
+	if attrs.VcsRepo != nil {
+		module.VcsRepo = &PrivateRegistryVcsRepo{
+			Branch:                  attrs.VcsRepo.Branch,
+			IngressSubmodules:       attrs.VcsRepo.IngressSubmodules,
+			Identifier:              attrs.VcsRepo.Identifier,
+			DisplayIdentifier:       attrs.VcsRepo.DisplayIdentifier,
+			GithubAppInstallationId: attrs.VcsRepo.GithubAppInstallationId,
+			RepositoryHttpUrl:       attrs.VcsRepo.RepositoryHttpUrl,
+			ServiceProvider:         attrs.VcsRepo.ServiceProvider,
+			Tags:                    attrs.VcsRepo.Tags,
+		}
+		if attrs.VcsRepo.TagsRegex != nil {
+			module.VcsRepo.TagsRegex = *attrs.VcsRepo.TagsRegex
+		}
+	}
+
+	for _, v := range attrs.VersionStatuses {
+		module.Versions = append(module.Versions, PrivateRegistryModuleVersion{
+			Version: v.Version,
+			Status:  v.Status,
+		})
+	}
+	sort.SliceStable(module.Versions, func(i, j int) bool {
+		return compareVersions(module.Versions[i].Version, module.Versions[j].Version) > 0
+	})
+
+	module.LatestVersion = latestPublishedVersion(module.Versions)
+
+	return module
+}
+
+// latestPublishedVersion returns the highest version with an "ok" status. Prereleases are only considered when no
+// stable version has been published. It returns an empty string if no version has been published.
+func latestPublishedVersion(versions []PrivateRegistryModuleVersion) string {
+	var latest, latestPrerelease string
+	for _, v := range versions {
+		if v.Status != "ok" {
+			continue
+		}
+		sv, err := parseSemver(v.Version)
+		if err != nil {
+			continue
+		}
+		if sv.Prerelease != "" {
+			if latestPrerelease == "" || compareVersions(v.Version, latestPrerelease) > 0 {
+				latestPrerelease = v.Version
+			}
+			continue
+		}
+		if latest == "" || compareVersions(v.Version, latest) > 0 {
+			latest = v.Version
+		}
+	}
+
+	if latest == "" {
+		return latestPrerelease
+	}
+	return latest
 }
 
 type privateRegistryApiResponse struct {
 	Data []struct {
-		Id         string `json:"id"`
-		Type       string `json:"type"`
-		Attributes struct {
-			Name            string `json:"name"`
-			Namespace       string `json:"namespace"`
-			Provider        string `json:"provider"`
-			Status          string `json:"status"`
-			VersionStatuses []struct {
-				Version string `json:"version"`
-				Status  string `json:"status"`
-			} `json:"version-statuses"`
-			CreatedAt           time.Time `json:"created-at"`
-			UpdatedAt           time.Time `json:"updated-at"`
-			RegistryName        string    `json:"registry-name"`
-			NoCode              bool      `json:"no-code"`
-			PublishingMechanism string    `json:"publishing-mechanism"`
-			VcsRepo             struct {
-				Branch                  string      `json:"branch"`
-				IngressSubmodules       bool        `json:"ingress-submodules"`
-				TagsRegex               interface{} `json:"tags-regex"`
-				Identifier              string      `json:"identifier"`
-				DisplayIdentifier       string      `json:"display-identifier"`
-				GithubAppInstallationId string      `json:"github-app-installation-id"`
-				RepositoryHttpUrl       string      `json:"repository-http-url"`
-				ServiceProvider         string      `json:"service-provider"`
-				Tags                    bool        `json:"tags"`
-			} `json:"vcs-repo"`
-			Permissions struct {
-				CanDelete bool `json:"can-delete"`
-				CanResync bool `json:"can-resync"`
-				CanRetry  bool `json:"can-retry"`
-			} `json:"permissions"`
-		} `json:"attributes"`
+		Id            string                          `json:"id"`
+		Type          string                          `json:"type"`
+		Attributes    privateRegistryModuleAttributes `json:"attributes"`
 		Relationships struct {
 			Organization struct {
 				Data struct {
@@ -134,21 +270,39 @@ type privateRegistryApiResponse struct {
 			Self string `json:"self"`
 		} `json:"links"`
 	} `json:"data"`
-	Links struct {
-		Self  string      `json:"self"`
-		First string      `json:"first"`
-		Prev  interface{} `json:"prev"`
-		Next  interface{} `json:"next"`
-		Last  string      `json:"last"`
-	} `json:"links"`
-	Meta struct {
-		Pagination struct {
-			CurrentPage int         `json:"current-page"`
-			PageSize    int         `json:"page-size"`
-			PrevPage    interface{} `json:"prev-page"`
-			NextPage    interface{} `json:"next-page"`
-			TotalPages  int         `json:"total-pages"`
-			TotalCount  int         `json:"total-count"`
-		} `json:"pagination"`
-	} `json:"meta"`
+	Links apiLinks `json:"links"`
+	Meta  apiMeta  `json:"meta"`
+}
+
+// privateRegistryModuleAttributes are the attributes of a module returned by the registry-modules API.
+type privateRegistryModuleAttributes struct {
+	Name            string `json:"name"`
+	Namespace       string `json:"namespace"`
+	Provider        string `json:"provider"`
+	Status          string `json:"status"`
+	VersionStatuses []struct {
+		Version string `json:"version"`
+		Status  string `json:"status"`
+	} `json:"version-statuses"`
+	CreatedAt           time.Time `json:"created-at"`
+	UpdatedAt           time.Time `json:"updated-at"`
+	RegistryName        string    `json:"registry-name"`
+	NoCode              bool      `json:"no-code"`
+	PublishingMechanism string    `json:"publishing-mechanism"`
+	VcsRepo             *struct {
+		Branch                  string  `json:"branch"`
+		IngressSubmodules       bool    `json:"ingress-submodules"`
+		TagsRegex               *string `json:"tags-regex"`
+		Identifier              string  `json:"identifier"`
+		DisplayIdentifier       string  `json:"display-identifier"`
+		GithubAppInstallationId string  `json:"github-app-installation-id"`
+		RepositoryHttpUrl       string  `json:"repository-http-url"`
+		ServiceProvider         string  `json:"service-provider"`
+		Tags                    bool    `json:"tags"`
+	} `json:"vcs-repo"`
+	Permissions struct {
+		CanDelete bool `json:"can-delete"`
+		CanResync bool `json:"can-resync"`
+		CanRetry  bool `json:"can-retry"`
+	} `json:"permissions"`
 }
@@ -0,0 +1,178 @@
+package cartographer
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// RegistryModule retrieves a single module from the organization's private registry by namespace, name and provider.
+// The module is populated the same way as the results of PrivateRegistryModules, including its full version history.
+func (c *Cartographer) RegistryModule(namespace, name, provider string) (*PrivateRegistryModule, error) {
+	baseUrl, err := buildRegistryModuleUrl(c.orgName, namespace, name, provider)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResponse registryModuleApiResponse
+	if err := c.getJSON(baseUrl, &apiResponse); err != nil {
+		return nil, err
+	}
+
+	module := newPrivateRegistryModule(apiResponse.Data.Id, apiResponse.Data.Type, apiResponse.Data.Attributes)
+	return &module, nil
+}
+
+// RegistryModuleVersion retrieves the details of a specific version of a private registry module: its root module
+// inputs, outputs, dependencies and resources along with the same details for every submodule. READMEs are not
+// included.
+func (c *Cartographer) RegistryModuleVersion(namespace, name, provider, version string) (*RegistryModuleVersionDetails, error) {
+	baseUrl, err := buildModuleRegistryProtocolUrl(namespace, name, provider, version)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResponse registryModuleVersionApiResponse
+	if err := c.getJSON(baseUrl, &apiResponse); err != nil {
+		return nil, err
+	}
+
+	details := &RegistryModuleVersionDetails{
+		Id:          apiResponse.Id,
+		Namespace:   apiResponse.Namespace,
+		Name:        apiResponse.Name,
+		Provider:    apiResponse.Provider,
+		Version:     apiResponse.Version,
+		Description: apiResponse.Description,
+		Source:      apiResponse.Source,
+		PublishedAt: apiResponse.PublishedAt,
+		Root:        newRegistryModuleDetails(apiResponse.Root),
+		Providers:   apiResponse.Providers,
+		Versions:    apiResponse.Versions,
+	}
+
+	for _, submodule := range apiResponse.Submodules {
+		details.Submodules = append(details.Submodules, newRegistryModuleDetails(submodule))
+	}
+
+	return details, nil
+}
+
+// RegistryModuleVersionDetails represents a single published version of a private registry module.
+type RegistryModuleVersionDetails struct {
+	Id          string                  `json:"id"`
+	Namespace   string                  `json:"namespace"`
+	Name        string                  `json:"name"`
+	Provider    string                  `json:"provider"`
+	Version     string                  `json:"version"`
+	Description string                  `json:"description"`
+	Source      string                  `json:"source"`
+	PublishedAt time.Time               `json:"published_at"`
+	Root        RegistryModuleDetails   `json:"root"`
+	Submodules  []RegistryModuleDetails `json:"submodules"`
+	Providers   []string                `json:"providers"`
+	Versions    []string                `json:"versions"`
+}
+
+// RegistryModuleDetails describes the interface of the root module or a submodule of a registry module version.
+type RegistryModuleDetails struct {
+	Path                 string                             `json:"path"`
+	Name                 string                             `json:"name"`
+	Empty                bool                               `json:"empty"`
+	Inputs               []RegistryModuleInput              `json:"inputs"`
+	Outputs              []RegistryModuleOutput             `json:"outputs"`
+	Dependencies         []RegistryModuleDependency         `json:"dependencies"`
+	ProviderDependencies []RegistryModuleProviderDependency `json:"provider_dependencies"`
+	Resources            []RegistryModuleResource           `json:"resources"`
+}
+
+// RegistryModuleInput represents an input variable of a registry module. Default holds the raw JSON default value and
+// is empty when the input has no default.
+type RegistryModuleInput struct {
+	Name        string          `json:"name"`
+	Type        string          `json:"type"`
+	Description string          `json:"description"`
+	Default     json.RawMessage `json:"default"`
+	Required    bool            `json:"required"`
+}
+
+// RegistryModuleOutput represents an output value of a registry module.
+type RegistryModuleOutput struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// RegistryModuleDependency represents a module called by a registry module.
+type RegistryModuleDependency struct {
+	Name    string `json:"name"`
+	Source  string `json:"source"`
+	Version string `json:"version"`
+}
+
+// RegistryModuleProviderDependency represents a provider required by a registry module.
+type RegistryModuleProviderDependency struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Source    string `json:"source"`
+	Version   string `json:"version"`
+}
+
+// RegistryModuleResource represents a resource declared by a registry module.
+type RegistryModuleResource struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// newRegistryModuleDetails converts a root or submodule from the module registry protocol into RegistryModuleDetails.
+func newRegistryModuleDetails(m registryModuleVersionModule) RegistryModuleDetails {
+	return RegistryModuleDetails{
+		Path:                 m.Path,
+		Name:                 m.Name,
+		Empty:                m.Empty,
+		Inputs:               m.Inputs,
+		Outputs:              m.Outputs,
+		Dependencies:         m.Dependencies,
+		ProviderDependencies: m.ProviderDependencies,
+		Resources:            m.Resources,
+	}
+}
+
+// registryModuleApiResponse is the response from the Terraform Cloud API for a single registry module.
+type registryModuleApiResponse struct {
+	Data struct {
+		Id         string                          `json:"id"`
+		Type       string                          `json:"type"`
+		Attributes privateRegistryModuleAttributes `json:"attributes"`
+	} `json:"data"`
+}
+
+// registryModuleVersionApiResponse is the response from the module registry protocol for a single module version.
+type registryModuleVersionApiResponse struct {
+	Id          string                        `json:"id"`
+	Owner       string                        `json:"owner"`
+	Namespace   string                        `json:"namespace"`
+	Name        string                        `json:"name"`
+	Version     string                        `json:"version"`
+	Provider    string                        `json:"provider"`
+	Description string                        `json:"description"`
+	Source      string                        `json:"source"`
+	PublishedAt time.Time                     `json:"published_at"`
+	Downloads   int                           `json:"downloads"`
+	Verified    bool                          `json:"verified"`
+	Root        registryModuleVersionModule   `json:"root"`
+	Submodules  []registryModuleVersionModule `json:"submodules"`
+	Providers   []string                      `json:"providers"`
+	Versions    []string                      `json:"versions"`
+}
+
+// registryModuleVersionModule is a root or submodule in the module registry protocol response.
+type registryModuleVersionModule struct {
+	Path                 string                             `json:"path"`
+	Name                 string                             `json:"name"`
+	Readme               string                             `json:"readme"`
+	Empty                bool                               `json:"empty"`
+	Inputs               []RegistryModuleInput              `json:"inputs"`
+	Outputs              []RegistryModuleOutput             `json:"outputs"`
+	Dependencies         []RegistryModuleDependency         `json:"dependencies"`
+	ProviderDependencies []RegistryModuleProviderDependency `json:"provider_dependencies"`
+	Resources            []RegistryModuleResource           `json:"resources"`
+}
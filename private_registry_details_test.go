@@ -0,0 +1,165 @@
+package cartographer
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestRegistryModule(t *testing.T) {
+	jsonResponse := `{
+		"data": {
+			"id": "mod-123",
+			"type": "registry-modules",
+			"attributes": {
+				"name": "vpc",
+				"namespace": "myOrgName",
+				"provider": "aws",
+				"registry-name": "private",
+				"version-statuses": [
+					{"version": "1.0.0", "status": "ok"},
+					{"version": "1.1.0", "status": "ok"}
+				]
+			}
+		}
+	}`
+
+	var path string
+	mockClient := &MockClient{
+		MockDo: func(req *http.Request) (*http.Response, error) {
+			path = req.URL.Path
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(jsonResponse)),
+			}, nil
+		},
+	}
+
+	c := &Cartographer{
+		client:  mockClient,
+		orgName: "test",
+		token:   "test",
+	}
+
+	module, err := c.RegistryModule("myOrgName", "vpc", "aws")
+	if err != nil {
+		t.Fatalf("RegistryModule() returned an error: %v", err)
+	}
+
+	if path != "/api/v2/organizations/test/registry-modules/private/myOrgName/vpc/aws" {
+		t.Errorf("RegistryModule() requested %v", path)
+	}
+	if module.Id != "mod-123" {
+		t.Errorf("RegistryModule() returned module with id %v, expected 'mod-123'", module.Id)
+	}
+	if module.LatestVersion != "1.1.0" {
+		t.Errorf("RegistryModule() returned module with latest version %v, expected '1.1.0'", module.LatestVersion)
+	}
+	if len(module.Versions) != 2 {
+		t.Errorf("RegistryModule() returned module with %v versions, expected 2", len(module.Versions))
+	}
+}
+
+func TestRegistryModuleVersion(t *testing.T) {
+	jsonResponse := `{
+		"id": "myOrgName/vpc/aws/1.1.0",
+		"namespace": "myOrgName",
+		"name": "vpc",
+		"version": "1.1.0",
+		"provider": "aws",
+		"source": "https://github.com/myOrgName/terraform-aws-vpc",
+		"published_at": "2023-06-01T00:00:00Z",
+		"root": {
+			"path": "",
+			"name": "vpc",
+			"readme": "# VPC",
+			"empty": false,
+			"inputs": [
+				{"name": "cidr", "type": "string", "description": "VPC CIDR", "default": "\"10.0.0.0/16\"", "required": false},
+				{"name": "name", "type": "string", "description": "VPC name", "required": true}
+			],
+			"outputs": [{"name": "vpc_id", "description": "The VPC ID"}],
+			"dependencies": [],
+			"provider_dependencies": [{"name": "aws", "namespace": "hashicorp", "source": "hashicorp/aws", "version": ">= 4.0"}],
+			"resources": [{"name": "this", "type": "aws_vpc"}]
+		},
+		"submodules": [
+			{
+				"path": "modules/subnets",
+				"name": "subnets",
+				"readme": "# Subnets",
+				"inputs": [{"name": "vpc_id", "type": "string", "required": true}],
+				"resources": [{"name": "this", "type": "aws_subnet"}]
+			}
+		],
+		"providers": ["aws"],
+		"versions": ["1.0.0", "1.1.0"]
+	}`
+
+	var path string
+	mockClient := &MockClient{
+		MockDo: func(req *http.Request) (*http.Response, error) {
+			path = req.URL.Path
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(jsonResponse)),
+			}, nil
+		},
+	}
+
+	c := &Cartographer{
+		client:  mockClient,
+		orgName: "test",
+		token:   "test",
+	}
+
+	details, err := c.RegistryModuleVersion("myOrgName", "vpc", "aws", "1.1.0")
+	if err != nil {
+		t.Fatalf("RegistryModuleVersion() returned an error: %v", err)
+	}
+
+	if path != "/api/registry/v1/modules/myOrgName/vpc/aws/1.1.0" {
+		t.Errorf("RegistryModuleVersion() requested %v", path)
+	}
+	if len(details.Root.Inputs) != 2 || !details.Root.Inputs[1].Required {
+		t.Errorf("RegistryModuleVersion() returned root inputs %+v", details.Root.Inputs)
+	}
+	if string(details.Root.Inputs[0].Default) != `"\"10.0.0.0/16\""` {
+		t.Errorf("RegistryModuleVersion() returned default %s", details.Root.Inputs[0].Default)
+	}
+	if len(details.Root.ProviderDependencies) != 1 || details.Root.ProviderDependencies[0].Source != "hashicorp/aws" {
+		t.Errorf("RegistryModuleVersion() returned provider dependencies %+v", details.Root.ProviderDependencies)
+	}
+	if len(details.Submodules) != 1 || details.Submodules[0].Path != "modules/subnets" {
+		t.Errorf("RegistryModuleVersion() returned submodules %+v", details.Submodules)
+	}
+	if len(details.Versions) != 2 {
+		t.Errorf("RegistryModuleVersion() returned %v versions, expected 2", len(details.Versions))
+	}
+}
+
+func TestRegistryModuleVersionNotFound(t *testing.T) {
+	mockClient := &MockClient{
+		MockDo: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 404,
+				Body:       io.NopCloser(strings.NewReader(`{"errors": ["Not Found"]}`)),
+			}, nil
+		},
+	}
+
+	c := &Cartographer{
+		client:  mockClient,
+		orgName: "test",
+		token:   "test",
+	}
+
+	_, err := c.RegistryModuleVersion("myOrgName", "vpc", "aws", "9.9.9")
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != 404 {
+		t.Errorf("RegistryModuleVersion() returned error %v, expected a 404 *APIError", err)
+	}
+}
@@ -0,0 +1,317 @@
+package cartographer
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+)
+
+// RegistryProviders retrieves every provider in the given organization's private registry. For private providers, each
+// published version is fetched along with the platforms it has binaries for. Public providers that have been added to
+// the registry are returned without versions as those are served by the public registry.
+func (c *Cartographer) RegistryProviders() ([]RegistryProvider, error) {
+	var providers []RegistryProvider
+
+	baseUrl, err := buildRegistryProvidersUrl(c.orgName)
+	if err != nil {
+		return nil, err
+	}
+
+	q := url.Values{}
+	q.Add("page[size]", "100")
+
+	baseUrl.RawQuery = q.Encode()
+
+	for {
+		var apiResponse registryProvidersApiResponse
+		if err := c.getJSON(baseUrl, &apiResponse); err != nil {
+			return nil, err
+		}
+
+		for _, item := range apiResponse.Data {
+			providers = append(providers, RegistryProvider{
+				Id:           item.Id,
+				Type:         item.Type,
+				Name:         item.Attributes.Name,
+				Namespace:    item.Attributes.Namespace,
+				RegistryName: item.Attributes.RegistryName,
+				CreatedAt:    item.Attributes.CreatedAt,
+				UpdatedAt:    item.Attributes.UpdatedAt,
+			})
+		}
+
+		if apiResponse.Meta.Pagination.NextPage == nil {
+			break
+		}
+
+		if apiResponse.Links.Next == nil {
+			break
+		}
+
+		baseUrl, err = url.Parse(apiResponse.Links.Next.(string))
+		if err != nil {
+			return nil, err
+		}
+
+		preventRateLimiting(apiResponse.Meta.Pagination.TotalPages)
+	}
+
+	// Each private provider costs at least one more request, so they are throttled like the pages of a query.
+	private := 0
+	for _, p := range providers {
+		if p.RegistryName == "private" {
+			private++
+		}
+	}
+
+	for i := range providers {
+		if providers[i].RegistryName != "private" {
+			continue
+		}
+
+		preventRateLimiting(private)
+		versions, err := c.registryProviderVersions(providers[i].Namespace, providers[i].Name)
+		if err != nil {
+			return nil, err
+		}
+
+		sort.SliceStable(versions, func(a, b int) bool {
+			return compareVersions(versions[a].Version, versions[b].Version) > 0
+		})
+		providers[i].Versions = versions
+		providers[i].LatestVersion = latestProviderVersion(versions)
+	}
+
+	return providers, nil
+}
+
+// latestProviderVersion returns the highest stable version of versions sorted from newest to oldest, or the highest
+// prerelease when no stable version has been published. It returns an empty string if there are no valid versions.
+func latestProviderVersion(versions []RegistryProviderVersion) string {
+	var latestPrerelease string
+	for _, v := range versions {
+		sv, err := parseSemver(v.Version)
+		if err != nil {
+			continue
+		}
+		if sv.Prerelease == "" {
+			return v.Version
+		}
+		if latestPrerelease == "" {
+			latestPrerelease = v.Version
+		}
+	}
+	return latestPrerelease
+}
+
+// registryProviderVersions retrieves every version of a private registry provider along with its platforms.
+func (c *Cartographer) registryProviderVersions(namespace, name string) ([]RegistryProviderVersion, error) {
+	var versions []RegistryProviderVersion
+
+	baseUrl, err := buildRegistryProvidersUrl(c.orgName, "private", namespace, name, "versions")
+	if err != nil {
+		return nil, err
+	}
+
+	q := url.Values{}
+	q.Add("page[size]", "100")
+
+	baseUrl.RawQuery = q.Encode()
+
+	for {
+		var apiResponse registryProviderVersionsApiResponse
+		if err := c.getJSON(baseUrl, &apiResponse); err != nil {
+			return nil, err
+		}
+
+		for _, item := range apiResponse.Data {
+			versions = append(versions, RegistryProviderVersion{
+				Id:                 item.Id,
+				Version:            item.Attributes.Version,
+				Protocols:          item.Attributes.Protocols,
+				KeyId:              item.Attributes.KeyId,
+				ShasumsUploaded:    item.Attributes.ShasumsUploaded,
+				ShasumsSigUploaded: item.Attributes.ShasumsSigUploaded,
+				CreatedAt:          item.Attributes.CreatedAt,
+				UpdatedAt:          item.Attributes.UpdatedAt,
+			})
+		}
+
+		if apiResponse.Meta.Pagination.NextPage == nil {
+			break
+		}
+
+		if apiResponse.Links.Next == nil {
+			break
+		}
+
+		baseUrl, err = url.Parse(apiResponse.Links.Next.(string))
+		if err != nil {
+			return nil, err
+		}
+
+		preventRateLimiting(apiResponse.Meta.Pagination.TotalPages)
+	}
+
+	// Each version costs another request, so they are throttled like the pages of a query.
+	for i := range versions {
+		preventRateLimiting(len(versions))
+		platforms, err := c.registryProviderPlatforms(namespace, name, versions[i].Version)
+		if err != nil {
+			return nil, err
+		}
+		versions[i].Platforms = platforms
+	}
+
+	return versions, nil
+}
+
+// registryProviderPlatforms retrieves every platform of a private registry provider version.
+func (c *Cartographer) registryProviderPlatforms(namespace, name, version string) ([]RegistryProviderPlatform, error) {
+	var platforms []RegistryProviderPlatform
+
+	baseUrl, err := buildRegistryProvidersUrl(c.orgName, "private", namespace, name, "versions", version, "platforms")
+	if err != nil {
+		return nil, err
+	}
+
+	q := url.Values{}
+	q.Add("page[size]", "100")
+
+	baseUrl.RawQuery = q.Encode()
+
+	for {
+		var apiResponse registryProviderPlatformsApiResponse
+		if err := c.getJSON(baseUrl, &apiResponse); err != nil {
+			return nil, err
+		}
+
+		for _, item := range apiResponse.Data {
+			platforms = append(platforms, RegistryProviderPlatform{
+				Id:                     item.Id,
+				Os:                     item.Attributes.Os,
+				Arch:                   item.Attributes.Arch,
+				Filename:               item.Attributes.Filename,
+				Shasum:                 item.Attributes.Shasum,
+				ProviderBinaryUploaded: item.Attributes.ProviderBinaryUploaded,
+			})
+		}
+
+		if apiResponse.Meta.Pagination.NextPage == nil {
+			break
+		}
+
+		if apiResponse.Links.Next == nil {
+			break
+		}
+
+		baseUrl, err = url.Parse(apiResponse.Links.Next.(string))
+		if err != nil {
+			return nil, err
+		}
+
+		preventRateLimiting(apiResponse.Meta.Pagination.TotalPages)
+	}
+
+	return platforms, nil
+}
+
+// RegistryProvider represents a provider in an organization's private registry.
+type RegistryProvider struct {
+	Id            string                    `json:"id"`
+	Type          string                    `json:"type"`
+	Name          string                    `json:"name"`
+	Namespace     string                    `json:"namespace"`
+	RegistryName  string                    `json:"registry_name"`
+	Versions      []RegistryProviderVersion `json:"versions"`
+	LatestVersion string                    `json:"latest_version"`
+	CreatedAt     time.Time                 `json:"created_at"`
+	UpdatedAt     time.Time                 `json:"updated_at"`
+}
+
+// Source returns the fully qualified source address Terraform configurations use to require the provider.
+func (p RegistryProvider) Source() string {
+	if p.RegistryName == "private" {
+		return fmt.Sprintf("app.terraform.io/%s/%s", p.Namespace, p.Name)
+	}
+	return fmt.Sprintf("registry.terraform.io/%s/%s", p.Namespace, p.Name)
+}
+
+// RegistryProviderVersion represents a published version of a private registry provider.
+type RegistryProviderVersion struct {
+	Id                 string                     `json:"id"`
+	Version            string                     `json:"version"`
+	Protocols          []string                   `json:"protocols"`
+	KeyId              string                     `json:"key_id"`
+	ShasumsUploaded    bool                       `json:"shasums_uploaded"`
+	ShasumsSigUploaded bool                       `json:"shasums_sig_uploaded"`
+	Platforms          []RegistryProviderPlatform `json:"platforms"`
+	CreatedAt          time.Time                  `json:"created_at"`
+	UpdatedAt          time.Time                  `json:"updated_at"`
+}
+
+// RegistryProviderPlatform represents an OS and architecture a private registry provider version is built for.
+type RegistryProviderPlatform struct {
+	Id                     string `json:"id"`
+	Os                     string `json:"os"`
+	Arch                   string `json:"arch"`
+	Filename               string `json:"filename"`
+	Shasum                 string `json:"shasum"`
+	ProviderBinaryUploaded bool   `json:"provider_binary_uploaded"`
+}
+
+// registryProvidersApiResponse is the response from the Terraform Cloud API for the registry-providers endpoint.
+type registryProvidersApiResponse struct {
+	Data []struct {
+		Id         string `json:"id"`
+		Type       string `json:"type"`
+		Attributes struct {
+			Name         string    `json:"name"`
+			Namespace    string    `json:"namespace"`
+			RegistryName string    `json:"registry-name"`
+			CreatedAt    time.Time `json:"created-at"`
+			UpdatedAt    time.Time `json:"updated-at"`
+		} `json:"attributes"`
+	} `json:"data"`
+	Links apiLinks `json:"links"`
+	Meta  apiMeta  `json:"meta"`
+}
+
+// registryProviderVersionsApiResponse is the response from the Terraform Cloud API for the versions of a registry
+// provider.
+type registryProviderVersionsApiResponse struct {
+	Data []struct {
+		Id         string `json:"id"`
+		Type       string `json:"type"`
+		Attributes struct {
+			Version            string    `json:"version"`
+			CreatedAt          time.Time `json:"created-at"`
+			UpdatedAt          time.Time `json:"updated-at"`
+			KeyId              string    `json:"key-id"`
+			Protocols          []string  `json:"protocols"`
+			ShasumsUploaded    bool      `json:"shasums-uploaded"`
+			ShasumsSigUploaded bool      `json:"shasums-sig-uploaded"`
+		} `json:"attributes"`
+	} `json:"data"`
+	Links apiLinks `json:"links"`
+	Meta  apiMeta  `json:"meta"`
+}
+
+// registryProviderPlatformsApiResponse is the response from the Terraform Cloud API for the platforms of a registry
+// provider version.
+type registryProviderPlatformsApiResponse struct {
+	Data []struct {
+		Id         string `json:"id"`
+		Type       string `json:"type"`
+		Attributes struct {
+			Os                     string `json:"os"`
+			Arch                   string `json:"arch"`
+			Filename               string `json:"filename"`
+			Shasum                 string `json:"shasum"`
+			ProviderBinaryUploaded bool   `json:"provider-binary-uploaded"`
+		} `json:"attributes"`
+	} `json:"data"`
+	Links apiLinks `json:"links"`
+	Meta  apiMeta  `json:"meta"`
+}
@@ -0,0 +1,111 @@
+package cartographer
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestRegistryProviders(t *testing.T) {
+	emptyPage := `{"data": [], "links": {"self": "test", "first": "test", "prev": null, "next": null, "last": "test"},
+		"meta": {"pagination": {"current-page": 1, "page-size": 100, "prev-page": null, "next-page": null, "total-pages": 1, "total-count": 0}}}`
+	responses := map[string]string{
+		"/api/v2/organizations/test/registry-providers": `{
+			"data": [
+				{"id": "prov-1", "type": "registry-providers", "attributes": {"name": "internal", "namespace": "test", "registry-name": "private"}},
+				{"id": "prov-2", "type": "registry-providers", "attributes": {"name": "aws", "namespace": "hashicorp", "registry-name": "public"}},
+				{"id": "prov-3", "type": "registry-providers", "attributes": {"name": "beta", "namespace": "test", "registry-name": "private"}}
+			],
+			"links": {"self": "test", "first": "test", "prev": null, "next": null, "last": "test"},
+			"meta": {"pagination": {"current-page": 1, "page-size": 100, "prev-page": null, "next-page": null, "total-pages": 1, "total-count": 2}}
+		}`,
+		"/api/v2/organizations/test/registry-providers/private/test/internal/versions": `{
+			"data": [
+				{"id": "provver-1", "type": "registry-provider-versions", "attributes": {"version": "1.2.0", "protocols": ["5.0"], "shasums-uploaded": true}},
+				{"id": "provver-2", "type": "registry-provider-versions", "attributes": {"version": "1.10.0", "protocols": ["6.0"], "shasums-uploaded": true}}
+			],
+			"links": {"self": "test", "first": "test", "prev": null, "next": null, "last": "test"},
+			"meta": {"pagination": {"current-page": 1, "page-size": 100, "prev-page": null, "next-page": null, "total-pages": 1, "total-count": 2}}
+		}`,
+		"/api/v2/organizations/test/registry-providers/private/test/beta/versions": `{
+			"data": [
+				{"id": "provver-3", "type": "registry-provider-versions", "attributes": {"version": "0.1.0-alpha"}},
+				{"id": "provver-4", "type": "registry-provider-versions", "attributes": {"version": "0.2.0-rc.1"}}
+			],
+			"links": {"self": "test", "first": "test", "prev": null, "next": null, "last": "test"},
+			"meta": {"pagination": {"current-page": 1, "page-size": 100, "prev-page": null, "next-page": null, "total-pages": 1, "total-count": 2}}
+		}`,
+		"/api/v2/organizations/test/registry-providers/private/test/beta/versions/0.1.0-alpha/platforms": emptyPage,
+		"/api/v2/organizations/test/registry-providers/private/test/beta/versions/0.2.0-rc.1/platforms":  emptyPage,
+		"/api/v2/organizations/test/registry-providers/private/test/internal/versions/1.2.0/platforms": `{
+			"data": [
+				{"id": "provpltfrm-1", "type": "registry-provider-platforms", "attributes": {"os": "linux", "arch": "amd64", "filename": "internal_1.2.0_linux_amd64.zip", "provider-binary-uploaded": true}}
+			],
+			"links": {"self": "test", "first": "test", "prev": null, "next": null, "last": "test"},
+			"meta": {"pagination": {"current-page": 1, "page-size": 100, "prev-page": null, "next-page": null, "total-pages": 1, "total-count": 1}}
+		}`,
+		"/api/v2/organizations/test/registry-providers/private/test/internal/versions/1.10.0/platforms": `{
+			"data": [
+				{"id": "provpltfrm-2", "type": "registry-provider-platforms", "attributes": {"os": "linux", "arch": "amd64"}},
+				{"id": "provpltfrm-3", "type": "registry-provider-platforms", "attributes": {"os": "darwin", "arch": "arm64"}}
+			],
+			"links": {"self": "test", "first": "test", "prev": null, "next": null, "last": "test"},
+			"meta": {"pagination": {"current-page": 1, "page-size": 100, "prev-page": null, "next-page": null, "total-pages": 1, "total-count": 2}}
+		}`,
+	}
+
+	mockClient := &MockClient{
+		MockDo: func(req *http.Request) (*http.Response, error) {
+			body, ok := responses[req.URL.Path]
+			if !ok {
+				t.Fatalf("unexpected request to %v", req.URL.Path)
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(body)),
+			}, nil
+		},
+	}
+
+	c := &Cartographer{
+		client:  mockClient,
+		orgName: "test",
+		token:   "test",
+	}
+
+	providers, err := c.RegistryProviders()
+	if err != nil {
+		t.Fatalf("RegistryProviders() returned an error: %v", err)
+	}
+
+	if len(providers) != 3 {
+		t.Fatalf("RegistryProviders() returned %v providers, expected 3", len(providers))
+	}
+
+	private := providers[0]
+	if private.Source() != "app.terraform.io/test/internal" {
+		t.Errorf("RegistryProviders() returned provider with source %v", private.Source())
+	}
+	if private.LatestVersion != "1.10.0" {
+		t.Errorf("RegistryProviders() returned provider with latest version %v, expected '1.10.0'", private.LatestVersion)
+	}
+	if len(private.Versions) != 2 || private.Versions[0].Version != "1.10.0" {
+		t.Fatalf("RegistryProviders() returned versions %+v", private.Versions)
+	}
+	if len(private.Versions[0].Platforms) != 2 || private.Versions[1].Platforms[0].Filename != "internal_1.2.0_linux_amd64.zip" {
+		t.Errorf("RegistryProviders() returned platforms %+v", private.Versions)
+	}
+
+	public := providers[1]
+	if public.Source() != "registry.terraform.io/hashicorp/aws" {
+		t.Errorf("RegistryProviders() returned provider with source %v", public.Source())
+	}
+	if len(public.Versions) != 0 {
+		t.Errorf("RegistryProviders() returned %v versions for a public provider, expected 0", len(public.Versions))
+	}
+
+	if prerelease := providers[2]; prerelease.LatestVersion != "0.2.0-rc.1" {
+		t.Errorf("RegistryProviders() returned latest version %q for a provider with only prereleases, expected '0.2.0-rc.1'", prerelease.LatestVersion)
+	}
+}
@@ -0,0 +1,338 @@
+package cartographer
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestPrivateRegistryModules(t *testing.T) {
+	jsonResponse := `{
+		"data": [
+			{
+				"id": "mod-123",
+				"type": "registry-modules",
+				"attributes": {
+					"name": "vpc",
+					"namespace": "myOrgName",
+					"provider": "aws",
+					"status": "setup_complete",
+					"version-statuses": [
+						{"version": "1.9.0", "status": "ok"},
+						{"version": "1.10.0", "status": "ok"},
+						{"version": "2.0.0-rc.1", "status": "ok"},
+						{"version": "1.11.0", "status": "errored"}
+					],
+					"created-at": "2023-01-01T00:00:00Z",
+					"updated-at": "2023-06-01T00:00:00Z",
+					"registry-name": "private",
+					"no-code": false,
+					"publishing-mechanism": "git_tag",
+					"vcs-repo": {
+						"branch": "",
+						"ingress-submodules": true,
+						"tags-regex": null,
+						"identifier": "myOrgName/terraform-aws-vpc",
+						"display-identifier": "myOrgName/terraform-aws-vpc",
+						"repository-http-url": "https://github.com/myOrgName/terraform-aws-vpc",
+						"service-provider": "github",
+						"tags": true
+					}
+				}
+			}
+		],
+		"links": {
+			"self": "test",
+			"first": "test",
+			"prev": null,
+			"next": null,
+			"last": "test"
+		},
+		"meta": {
+			"pagination": {
+				"current-page": 1,
+				"page-size": 100,
+				"prev-page": null,
+				"next-page": null,
+				"total-pages": 1,
+				"total-count": 1
+			}
+		}
+	}`
+
+	mockClient := &MockClient{
+		MockDo: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(jsonResponse)),
+			}, nil
+		},
+	}
+
+	c := &Cartographer{
+		client:  mockClient,
+		orgName: "test",
+		token:   "test",
+	}
+
+	modules, err := c.PrivateRegistryModules()
+	if err != nil {
+		t.Fatalf("PrivateRegistryModules() returned an error: %v", err)
+	}
+
+	if len(modules) != 1 {
+		t.Fatalf("PrivateRegistryModules() returned %v modules, expected 1", len(modules))
+	}
+
+	module := modules[0]
+	if module.Namespace != "myOrgName" {
+		t.Errorf("PrivateRegistryModules() returned module with namespace %v, expected 'myOrgName'", module.Namespace)
+	}
+	if module.Provider != "aws" {
+		t.Errorf("PrivateRegistryModules() returned module with provider %v, expected 'aws'", module.Provider)
+	}
+	if module.RegistryName != "private" {
+		t.Errorf("PrivateRegistryModules() returned module with registry name %v, expected 'private'", module.RegistryName)
+	}
+	if module.VcsRepo == nil || module.VcsRepo.Identifier != "myOrgName/terraform-aws-vpc" {
+		t.Errorf("PrivateRegistryModules() returned module with vcs repo %+v", module.VcsRepo)
+	}
+	if len(module.Versions) != 4 {
+		t.Fatalf("PrivateRegistryModules() returned module with %v versions, expected 4", len(module.Versions))
+	}
+	if module.Versions[0].Version != "2.0.0-rc.1" || module.Versions[3].Version != "1.9.0" {
+		t.Errorf("PrivateRegistryModules() returned unsorted versions %+v", module.Versions)
+	}
+	if module.LatestVersion != "1.10.0" {
+		t.Errorf("PrivateRegistryModules() returned module with latest version %v, expected '1.10.0'", module.LatestVersion)
+	}
+}
+
+func TestLatestPublishedVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		versions []PrivateRegistryModuleVersion
+		expected string
+	}{
+		{
+			name:     "no versions",
+			expected: "",
+		},
+		{
+			name: "only prereleases",
+			versions: []PrivateRegistryModuleVersion{
+				{Version: "1.0.0-beta.1", Status: "ok"},
+				{Version: "1.0.0-beta.2", Status: "ok"},
+			},
+			expected: "1.0.0-beta.2",
+		},
+		{
+			name: "nothing published",
+			versions: []PrivateRegistryModuleVersion{
+				{Version: "1.0.0", Status: "pending"},
+			},
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		if got := latestPublishedVersion(tt.versions); got != tt.expected {
+			t.Errorf("%s: latestPublishedVersion() returned %q, expected %q", tt.name, got, tt.expected)
+		}
+	}
+}
+
+// trackingBody records whether the response body was closed.
+type trackingBody struct {
+	io.Reader
+	closed bool
+}
+
+func (b *trackingBody) Close() error {
+	b.closed = true
+	return nil
+}
+
+func TestPrivateRegistryModulesErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		header     http.Header
+		body       string
+		rateLimit  bool
+		apiError   bool
+	}{
+		{
+			name:       "unauthorized",
+			statusCode: 401,
+			body:       `{"errors": [{"status": "401", "title": "unauthorized"}]}`,
+			apiError:   true,
+		},
+		{
+			name:       "rate limited",
+			statusCode: 429,
+			header:     http.Header{"X-Ratelimit-Limit": []string{"30"}},
+			body:       `{"errors": [{"status": "429", "title": "Too many requests"}]}`,
+			apiError:   true,
+			rateLimit:  true,
+		},
+		{
+			name:       "malformed json",
+			statusCode: 200,
+			body:       `{"data": [`,
+		},
+	}
+
+	for _, tt := range tests {
+		body := &trackingBody{Reader: strings.NewReader(tt.body)}
+		mockClient := &MockClient{
+			MockDo: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: tt.statusCode,
+					Header:     tt.header,
+					Body:       body,
+				}, nil
+			},
+		}
+
+		c := &Cartographer{
+			client:  mockClient,
+			orgName: "test",
+			token:   "test",
+		}
+
+		modules, err := c.PrivateRegistryModules()
+		if err == nil {
+			t.Errorf("%s: PrivateRegistryModules() expected an error, got %v", tt.name, modules)
+			continue
+		}
+
+		var apiErr *APIError
+		if errors.As(err, &apiErr) != tt.apiError {
+			t.Errorf("%s: PrivateRegistryModules() returned error %v, expected *APIError: %v", tt.name, err, tt.apiError)
+		}
+		if tt.apiError && apiErr.StatusCode != tt.statusCode {
+			t.Errorf("%s: PrivateRegistryModules() returned status code %v, expected %v", tt.name, apiErr.StatusCode, tt.statusCode)
+		}
+		if tt.rateLimit && (!apiErr.RateLimited() || apiErr.RateLimit != "30") {
+			t.Errorf("%s: PrivateRegistryModules() returned %+v, expected a rate limit of 30", tt.name, apiErr)
+		}
+		if !body.closed {
+			t.Errorf("%s: PrivateRegistryModules() did not close the response body", tt.name)
+		}
+	}
+}
+
+func TestPrivateRegistryModulesWithoutVersions(t *testing.T) {
+	jsonResponse := `{
+		"data": [
+			{
+				"id": "mod-123",
+				"type": "registry-modules",
+				"attributes": {
+					"name": "empty",
+					"namespace": "myOrgName",
+					"provider": "aws",
+					"status": "pending",
+					"version-statuses": [],
+					"vcs-repo": null
+				}
+			}
+		],
+		"links": {"self": "test", "first": "test", "prev": null, "next": null, "last": "test"},
+		"meta": {"pagination": {"current-page": 1, "page-size": 100, "prev-page": null, "next-page": null, "total-pages": 1, "total-count": 1}}
+	}`
+
+	body := &trackingBody{Reader: strings.NewReader(jsonResponse)}
+	mockClient := &MockClient{
+		MockDo: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 200,
+				Body:       body,
+			}, nil
+		},
+	}
+
+	c := &Cartographer{
+		client:  mockClient,
+		orgName: "test",
+		token:   "test",
+	}
+
+	modules, err := c.PrivateRegistryModules()
+	if err != nil {
+		t.Fatalf("PrivateRegistryModules() returned an error: %v", err)
+	}
+
+	if len(modules) != 1 {
+		t.Fatalf("PrivateRegistryModules() returned %v modules, expected 1", len(modules))
+	}
+	if modules[0].Published() {
+		t.Errorf("PrivateRegistryModules() returned module without versions marked as published")
+	}
+	if modules[0].VcsRepo != nil {
+		t.Errorf("PrivateRegistryModules() returned module with vcs repo %+v, expected nil", modules[0].VcsRepo)
+	}
+	if !body.closed {
+		t.Errorf("PrivateRegistryModules() did not close the response body")
+	}
+}
+
+func TestSearchPrivateRegistryModules(t *testing.T) {
+	jsonResponse := `{
+		"data": [
+			{"id": "mod-1", "type": "registry-modules", "attributes": {"name": "vpc", "namespace": "myOrgName", "provider": "aws", "registry-name": "private"}},
+			{"id": "mod-2", "type": "registry-modules", "attributes": {"name": "vpc", "namespace": "terraform-aws-modules", "provider": "aws", "registry-name": "public"}}
+		],
+		"links": {"self": "test", "first": "test", "prev": null, "next": null, "last": "test"},
+		"meta": {"pagination": {"current-page": 1, "page-size": 100, "prev-page": null, "next-page": null, "total-pages": 1, "total-count": 2}}
+	}`
+
+	var query url.Values
+	mockClient := &MockClient{
+		MockDo: func(req *http.Request) (*http.Response, error) {
+			query = req.URL.Query()
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(jsonResponse)),
+			}, nil
+		},
+	}
+
+	c := &Cartographer{
+		client:  mockClient,
+		orgName: "test",
+		token:   "test",
+	}
+
+	modules, err := c.SearchPrivateRegistryModules(RegistryModuleOptions{
+		Search:           "vpc",
+		Provider:         "aws",
+		Namespace:        "myOrgName",
+		Registry:         PrivateRegistry,
+		OrganizationName: "myOrgName",
+	})
+	if err != nil {
+		t.Fatalf("SearchPrivateRegistryModules() returned an error: %v", err)
+	}
+
+	if query.Get("q") != "vpc" {
+		t.Errorf("SearchPrivateRegistryModules() sent q=%v, expected 'vpc'", query.Get("q"))
+	}
+	if query.Get("filter[provider]") != "aws" {
+		t.Errorf("SearchPrivateRegistryModules() sent filter[provider]=%v, expected 'aws'", query.Get("filter[provider]"))
+	}
+	if query.Get("filter[registry_name]") != "private" {
+		t.Errorf("SearchPrivateRegistryModules() sent filter[registry_name]=%v, expected 'private'", query.Get("filter[registry_name]"))
+	}
+	if query.Get("filter[organization_name]") != "myOrgName" {
+		t.Errorf("SearchPrivateRegistryModules() sent filter[organization_name]=%v, expected 'myOrgName'", query.Get("filter[organization_name]"))
+	}
+
+	if len(modules) != 1 || modules[0].Id != "mod-1" {
+		t.Errorf("SearchPrivateRegistryModules() returned %+v, expected only mod-1", modules)
+	}
+}
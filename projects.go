@@ -0,0 +1,117 @@
+package cartographer
+
+import (
+	"sort"
+	"time"
+)
+
+// Projects retrieves every workspace in the organization and rolls them up by project.
+func (c *Cartographer) Projects() ([]ProjectSummary, error) {
+	workspaces, err := c.Workspaces(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return SummarizeProjects(workspaces), nil
+}
+
+// SummarizeProjects groups the given workspaces by project external ID and aggregates them into one ProjectSummary per
+// project, sorted by project name.
+func SummarizeProjects(workspaces []Workspace) []ProjectSummary {
+	projects := make(map[string]*ProjectSummary)
+	modules := make(map[string]map[string]bool)
+	providers := make(map[string]map[string]bool)
+
+	for _, w := range workspaces {
+		p, ok := projects[w.ProjectExternalId]
+		if !ok {
+			p = &ProjectSummary{
+				ProjectName:       w.ProjectName,
+				ProjectExternalId: w.ProjectExternalId,
+				TerraformVersions: make(map[string]int),
+			}
+			projects[w.ProjectExternalId] = p
+			modules[w.ProjectExternalId] = make(map[string]bool)
+			providers[w.ProjectExternalId] = make(map[string]bool)
+		}
+
+		p.Workspaces++
+		p.WorkspaceNames = append(p.WorkspaceNames, w.WorkspaceName)
+		p.ResourcesDrifted += w.ResourcesDrifted
+		p.ResourcesUndrifted += w.ResourcesUndrifted
+		if w.Drifted {
+			p.DriftedWorkspaces++
+		}
+
+		p.ChecksPassed += w.ChecksPassed
+		p.ChecksFailed += w.ChecksFailed
+		p.ChecksErrored += w.ChecksErrored
+		p.ChecksUnknown += w.ChecksUnknown
+		if w.ChecksFailed+w.ChecksErrored > 0 {
+			p.FailingChecksWorkspaces++
+		}
+
+		p.TerraformVersions[w.WorkspaceTerraformVersion]++
+
+		p.ModuleCount += w.ModuleCount
+		for _, m := range w.Modules {
+			modules[w.ProjectExternalId][m.Name] = true
+		}
+		p.ProviderCount += w.ProviderCount
+		for _, name := range w.ProviderNames() {
+			providers[w.ProjectExternalId][name] = true
+		}
+
+		for _, activity := range []*time.Time{w.CurrentRunAppliedAt, &w.WorkspaceUpdatedAt} {
+			if activity == nil || activity.IsZero() {
+				continue
+			}
+			if p.LastActivityAt == nil || activity.After(*p.LastActivityAt) {
+				at := *activity
+				p.LastActivityAt = &at
+			}
+		}
+	}
+
+	var summaries []ProjectSummary
+	for id, p := range projects {
+		p.UniqueModules = len(modules[id])
+		p.UniqueProviders = len(providers[id])
+		sort.Strings(p.WorkspaceNames)
+		summaries = append(summaries, *p)
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].ProjectName != summaries[j].ProjectName {
+			return summaries[i].ProjectName < summaries[j].ProjectName
+		}
+		return summaries[i].ProjectExternalId < summaries[j].ProjectExternalId
+	})
+
+	return summaries
+}
+
+// ProjectSummary aggregates the workspaces belonging to a project. ModuleCount and ProviderCount are summed across
+// workspaces, while UniqueModules and UniqueProviders count distinct module and provider names. TerraformVersions maps
+// each configured Terraform version to the number of workspaces using it. LastActivityAt is the most recent apply or
+// update of any workspace in the project.
+type ProjectSummary struct {
+	ProjectName             string         `json:"project-name"`
+	ProjectExternalId       string         `json:"project-external-id"`
+	Workspaces              int            `json:"workspaces"`
+	WorkspaceNames          []string       `json:"workspace-names"`
+	DriftedWorkspaces       int            `json:"drifted-workspaces"`
+	ResourcesDrifted        int            `json:"resources-drifted"`
+	ResourcesUndrifted      int            `json:"resources-undrifted"`
+	FailingChecksWorkspaces int            `json:"failing-checks-workspaces"`
+	ChecksPassed            int            `json:"checks-passed"`
+	ChecksFailed            int            `json:"checks-failed"`
+	ChecksErrored           int            `json:"checks-errored"`
+	ChecksUnknown           int            `json:"checks-unknown"`
+	TerraformVersions       map[string]int `json:"terraform-versions"`
+	ModuleCount             int            `json:"module-count"`
+	UniqueModules           int            `json:"unique-modules"`
+	ProviderCount           int            `json:"provider-count"`
+	UniqueProviders         int            `json:"unique-providers"`
+	LastActivityAt          *time.Time     `json:"last-activity-at"`
+}
@@ -0,0 +1,75 @@
+package cartographer
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSummarizeProjects(t *testing.T) {
+	applied := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	workspaces := []Workspace{
+		{
+			WorkspaceName:             "network-prod",
+			ProjectName:               "network",
+			ProjectExternalId:         "prj-1",
+			WorkspaceTerraformVersion: "1.6.0",
+			Drifted:                   true,
+			ResourcesDrifted:          2,
+			ChecksFailed:              1,
+			ModuleCount:               2,
+			Modules:                   []WorkspaceModule{{Name: "vpc", Version: "1.0.0"}, {Name: "tags", Version: "0.1.0"}},
+			ProviderCount:             1,
+			Providers:                 "aws",
+			CurrentRunAppliedAt:       &applied,
+			WorkspaceUpdatedAt:        time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			WorkspaceName:             "network-dev",
+			ProjectName:               "network",
+			ProjectExternalId:         "prj-1",
+			WorkspaceTerraformVersion: "1.5.0",
+			ChecksPassed:              2,
+			ModuleCount:               1,
+			Modules:                   []WorkspaceModule{{Name: "vpc", Version: "1.1.0"}},
+			ProviderCount:             2,
+			Providers:                 "aws,random",
+			WorkspaceUpdatedAt:        time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			WorkspaceName:             "app",
+			ProjectName:               "apps",
+			ProjectExternalId:         "prj-2",
+			WorkspaceTerraformVersion: "1.6.0",
+		},
+	}
+
+	summaries := SummarizeProjects(workspaces)
+
+	if len(summaries) != 2 {
+		t.Fatalf("SummarizeProjects() returned %v projects, expected 2", len(summaries))
+	}
+
+	apps := summaries[0]
+	if apps.ProjectName != "apps" || apps.Workspaces != 1 || apps.LastActivityAt != nil {
+		t.Errorf("SummarizeProjects() returned %+v, expected apps with one inactive workspace", apps)
+	}
+
+	network := summaries[1]
+	if network.Workspaces != 2 || network.DriftedWorkspaces != 1 || network.FailingChecksWorkspaces != 1 {
+		t.Errorf("SummarizeProjects() returned %+v", network)
+	}
+	if !reflect.DeepEqual(network.TerraformVersions, map[string]int{"1.6.0": 1, "1.5.0": 1}) {
+		t.Errorf("SummarizeProjects() returned terraform versions %v", network.TerraformVersions)
+	}
+	if network.ModuleCount != 3 || network.UniqueModules != 2 || network.ProviderCount != 3 || network.UniqueProviders != 2 {
+		t.Errorf("SummarizeProjects() returned modules %d/%d and providers %d/%d, expected 3/2 and 3/2",
+			network.ModuleCount, network.UniqueModules, network.ProviderCount, network.UniqueProviders)
+	}
+	if network.LastActivityAt == nil || !network.LastActivityAt.Equal(time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("SummarizeProjects() returned last activity %v, expected 2024-06-01", network.LastActivityAt)
+	}
+	if !reflect.DeepEqual(network.WorkspaceNames, []string{"network-dev", "network-prod"}) {
+		t.Errorf("SummarizeProjects() returned workspace names %v", network.WorkspaceNames)
+	}
+}
@@ -0,0 +1,132 @@
+package cartographer
+
+import (
+	"sort"
+	"strings"
+)
+
+// ProviderVersionSkew retrieves every provider in use across the organization and builds a skew report from them.
+// Workspaces using a version more than maxMinorsBehind minor versions behind the newest version in use are flagged.
+func (c *Cartographer) ProviderVersionSkew(maxMinorsBehind int) ([]ProviderSkew, error) {
+	providers, err := c.Providers(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewProviderSkewReport(providers, maxMinorsBehind), nil
+}
+
+// NewProviderSkewReport groups Explorer provider rows by source and reports the versions in use for each provider. Rows
+// with the same source and version are merged and each workspace is counted once, falling back to the rows' workspace
+// counts when they do not list workspace names. The
+// dominant version is the one used by the most workspaces, with ties going to the newer version. A version is flagged
+// when it is on an older major version than the newest version in use, or is more than maxMinorsBehind minor versions
+// behind it. Providers are sorted by the number of versions in use, highest first, then by source.
+func NewProviderSkewReport(providers []Provider, maxMinorsBehind int) []ProviderSkew {
+	bySource := make(map[string]*ProviderSkew)
+	byVersion := make(map[string]map[string]*ProviderVersionUsage)
+	unnamed := make(map[*ProviderVersionUsage]int)
+	var order []string
+	for _, p := range providers {
+		source := strings.ToLower(p.Source)
+		skew, ok := bySource[source]
+		if !ok {
+			skew = &ProviderSkew{Source: p.Source, Name: p.Name}
+			bySource[source] = skew
+			byVersion[source] = make(map[string]*ProviderVersionUsage)
+			order = append(order, source)
+		}
+
+		v, ok := byVersion[source][p.Version]
+		if !ok {
+			v = &ProviderVersionUsage{Version: p.Version}
+			byVersion[source][p.Version] = v
+		}
+		workspaces := p.WorkspaceNames()
+		if len(workspaces) == 0 {
+			unnamed[v] += p.WorkspaceCount
+		}
+		for _, w := range workspaces {
+			v.Workspaces = appendUnique(v.Workspaces, w)
+		}
+	}
+
+	var report []ProviderSkew
+	for _, source := range order {
+		skew := bySource[source]
+		for _, v := range byVersion[source] {
+			sort.Strings(v.Workspaces)
+			v.WorkspaceCount = len(v.Workspaces) + unnamed[v]
+			skew.Versions = append(skew.Versions, *v)
+		}
+		sort.Slice(skew.Versions, func(i, j int) bool {
+			if c := compareVersions(skew.Versions[i].Version, skew.Versions[j].Version); c != 0 {
+				return c > 0
+			}
+			return skew.Versions[i].Version < skew.Versions[j].Version
+		})
+
+		skew.LatestVersion = skew.Versions[0].Version
+		dominant := skew.Versions[0]
+		for _, v := range skew.Versions[1:] {
+			if v.WorkspaceCount > dominant.WorkspaceCount {
+				dominant = v
+			}
+		}
+		skew.DominantVersion = dominant.Version
+
+		latest, latestErr := parseSemver(skew.LatestVersion)
+		for i := range skew.Versions {
+			v := &skew.Versions[i]
+			current, err := parseSemver(v.Version)
+			if latestErr != nil || err != nil {
+				continue
+			}
+
+			v.MajorsBehind = latest.Major - current.Major
+			if v.MajorsBehind == 0 {
+				v.MinorsBehind = latest.Minor - current.Minor
+			}
+			v.Flagged = v.MajorsBehind > 0 || v.MinorsBehind > maxMinorsBehind
+			if v.Flagged {
+				for _, w := range v.Workspaces {
+					skew.FlaggedWorkspaces = appendUnique(skew.FlaggedWorkspaces, w)
+				}
+			}
+		}
+		sort.Strings(skew.FlaggedWorkspaces)
+
+		report = append(report, *skew)
+	}
+
+	sort.SliceStable(report, func(i, j int) bool {
+		if len(report[i].Versions) != len(report[j].Versions) {
+			return len(report[i].Versions) > len(report[j].Versions)
+		}
+		return report[i].Source < report[j].Source
+	})
+
+	return report
+}
+
+// ProviderSkew represents the versions of a single provider in use across an organization. Versions are sorted newest
+// first.
+type ProviderSkew struct {
+	Source            string                 `json:"source"`
+	Name              string                 `json:"name"`
+	LatestVersion     string                 `json:"latest-version"`
+	DominantVersion   string                 `json:"dominant-version"`
+	Versions          []ProviderVersionUsage `json:"versions"`
+	FlaggedWorkspaces []string               `json:"flagged-workspaces"`
+}
+
+// ProviderVersionUsage represents a single version of a provider and the workspaces using it. MinorsBehind is only set
+// for versions on the same major version as the newest version in use.
+type ProviderVersionUsage struct {
+	Version        string   `json:"version"`
+	WorkspaceCount int      `json:"workspace-count"`
+	Workspaces     []string `json:"workspaces"`
+	MajorsBehind   int      `json:"majors-behind"`
+	MinorsBehind   int      `json:"minors-behind"`
+	Flagged        bool     `json:"flagged"`
+}
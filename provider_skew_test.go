@@ -0,0 +1,49 @@
+package cartographer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewProviderSkewReport(t *testing.T) {
+	providers := []Provider{
+		{Name: "aws", Source: "hashicorp/aws", Version: "5.30.0", WorkspaceCount: 1, Workspaces: "a"},
+		{Name: "aws", Source: "hashicorp/aws", Version: "5.10.0", WorkspaceCount: 3, Workspaces: "b,c,d"},
+		{Name: "aws", Source: "hashicorp/aws", Version: "5.28.1", WorkspaceCount: 1, Workspaces: "e"},
+		{Name: "aws", Source: "hashicorp/aws", Version: "4.67.0", WorkspaceCount: 1, Workspaces: "f"},
+		{Name: "random", Source: "hashicorp/random", Version: "3.5.1", WorkspaceCount: 2, Workspaces: "a,b"},
+		// A second row for the same source and version shares workspace f and adds workspace d.
+		{Name: "aws", Source: "HashiCorp/aws", Version: "4.67.0", WorkspaceCount: 2, Workspaces: "f,d"},
+	}
+
+	report := NewProviderSkewReport(providers, 5)
+
+	if len(report) != 2 {
+		t.Fatalf("NewProviderSkewReport() returned %v providers, expected 2", len(report))
+	}
+
+	aws := report[0]
+	if aws.Source != "hashicorp/aws" || len(aws.Versions) != 4 {
+		t.Fatalf("NewProviderSkewReport() returned %+v, expected hashicorp/aws with 4 versions first", aws)
+	}
+	if aws.LatestVersion != "5.30.0" || aws.DominantVersion != "5.10.0" {
+		t.Errorf("NewProviderSkewReport() returned latest %v and dominant %v, expected 5.30.0 and 5.10.0", aws.LatestVersion, aws.DominantVersion)
+	}
+	if aws.Versions[1].Version != "5.28.1" || aws.Versions[1].MinorsBehind != 2 || aws.Versions[1].Flagged {
+		t.Errorf("NewProviderSkewReport() returned %+v, expected 5.28.1 two minors behind and not flagged", aws.Versions[1])
+	}
+	if v := aws.Versions[3]; v.WorkspaceCount != 2 || !reflect.DeepEqual(v.Workspaces, []string{"d", "f"}) {
+		t.Errorf("NewProviderSkewReport() returned %+v, expected the 4.67.0 rows merged with workspaces d and f", v)
+	}
+	if aws.Versions[3].MajorsBehind != 1 || !aws.Versions[3].Flagged {
+		t.Errorf("NewProviderSkewReport() returned %+v, expected 4.67.0 flagged a major behind", aws.Versions[3])
+	}
+	if !reflect.DeepEqual(aws.FlaggedWorkspaces, []string{"b", "c", "d", "f"}) {
+		t.Errorf("NewProviderSkewReport() returned flagged workspaces %v", aws.FlaggedWorkspaces)
+	}
+
+	random := report[1]
+	if random.DominantVersion != "3.5.1" || len(random.FlaggedWorkspaces) != 0 {
+		t.Errorf("NewProviderSkewReport() returned %+v", random)
+	}
+}
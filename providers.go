@@ -30,11 +30,18 @@ type ProviderFilter struct {
 
 // Providers Retrieve a list of providers across all workspaces in an organization.
 func (c *Cartographer) Providers(filters []ProviderFilter) ([]Provider, error) {
+	providers, _, err := c.ProvidersWithMeta(filters)
+	return providers, err
+}
+
+// ProvidersWithMeta behaves like Providers but also returns the links and pagination metadata of the query.
+func (c *Cartographer) ProvidersWithMeta(filters []ProviderFilter) ([]Provider, *QueryMeta, error) {
 	var providers []Provider
+	var meta *QueryMeta
 
 	baseUrl, err := buildExplorerUrl(c.orgName)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	q := url.Values{}
@@ -50,7 +57,7 @@ func (c *Cartographer) Providers(filters []ProviderFilter) ([]Provider, error) {
 
 	req, err := http.NewRequest("GET", baseUrl.String(), nil)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	req.Header.Set("Authorization", "Bearer "+c.token)
@@ -58,22 +65,39 @@ func (c *Cartographer) Providers(filters []ProviderFilter) ([]Provider, error) {
 	for {
 		res, err := c.client.Do(req)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		if err := checkStatusCode(res); err != nil {
-			return nil, err
+			res.Body.Close()
+			return nil, nil, err
 		}
 
 		var apiResponse providerApiResponse
-		if err := json.NewDecoder(res.Body).Decode(&apiResponse); err != nil {
-			return nil, err
+		err = json.NewDecoder(res.Body).Decode(&apiResponse)
+		res.Body.Close()
+		if err != nil {
+			return nil, nil, err
 		}
 
 		for _, item := range apiResponse.Data {
-			providers = append(providers, item.Attributes)
+			providers = append(providers, Provider{
+				Id:             item.Id,
+				Type:           item.Type,
+				Name:           item.Attributes.Name,
+				Source:         item.Attributes.Source,
+				Version:        item.Attributes.Version,
+				RegistryType:   item.Attributes.RegistryType,
+				WorkspaceCount: item.Attributes.WorkspaceCount,
+				Workspaces:     item.Attributes.Workspaces,
+			})
 		}
 
+		if meta == nil {
+			meta = newQueryMeta(apiResponse.Links, apiResponse.Meta)
+		}
+		meta.PagesFetched++
+
 		if apiResponse.Meta.Pagination.NextPage == nil {
 			break
 		}
@@ -84,18 +108,19 @@ func (c *Cartographer) Providers(filters []ProviderFilter) ([]Provider, error) {
 
 		req.URL, err = url.Parse(apiResponse.Links.Next.(string))
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
-		res.Body.Close()
 
 		preventRateLimiting(apiResponse.Meta.Pagination.TotalPages)
 	}
 
-	return providers, nil
+	return providers, meta, nil
 }
 
 // Provider represents a Terraform Cloud provider.
 type Provider struct {
+	Id             string `json:"id"`
+	Type           string `json:"type"`
 	Name           string `json:"name"`
 	Source         string `json:"source"`
 	Version        string `json:"version"`
@@ -104,6 +129,11 @@ type Provider struct {
 	Workspaces     string `json:"workspaces"`
 }
 
+// WorkspaceNames returns the names of the workspaces using the provider.
+func (p Provider) WorkspaceNames() []string {
+	return splitList(p.Workspaces)
+}
+
 // providerApiResponse is the response from the Terraform Cloud API for the providers endpoint.
 type providerApiResponse struct {
 	Data []struct {
@@ -118,21 +148,6 @@ type providerApiResponse struct {
 		Id   string `json:"id"`
 		Type string `json:"type"`
 	} `json:"data"`
-	Links struct {
-		Self  string      `json:"self"`
-		First string      `json:"first"`
-		Last  string      `json:"last"`
-		Prev  interface{} `json:"prev"`
-		Next  interface{} `json:"next"`
-	} `json:"links"`
-	Meta struct {
-		Pagination struct {
-			CurrentPage int         `json:"current-page"`
-			PageSize    int         `json:"page-size"`
-			NextPage    interface{} `json:"next-page"`
-			PrevPage    interface{} `json:"prev-page"`
-			TotalPages  int         `json:"total-pages"`
-			TotalCount  int         `json:"total-count"`
-		} `json:"pagination"`
-	} `json:"meta"`
+	Links apiLinks `json:"links"`
+	Meta  apiMeta  `json:"meta"`
 }
@@ -67,6 +67,12 @@ func TestProviders(t *testing.T) {
 	}
 
 	module := modules[0]
+	if module.Id != "test" {
+		t.Errorf("Providers() returned module with id %v, expected 'test'", module.Id)
+	}
+	if module.Type != "test" {
+		t.Errorf("Providers() returned module with type %v, expected 'test'", module.Type)
+	}
 	if module.Name != "testname" {
 		t.Errorf("Providers() returned module with name %v, expected 'test'", module.Name)
 	}
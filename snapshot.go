@@ -0,0 +1,109 @@
+package cartographer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// SnapshotFormatVersion is the version of the JSON format written by Snapshot.Write. It is incremented whenever the
+// format changes in a way older readers cannot handle.
+const SnapshotFormatVersion = 1
+
+// Snapshot captures the Explorer and private registry data of an organization at a point in time so that it can be
+// saved to disk and analysed offline.
+type Snapshot struct {
+	FormatVersion    int                     `json:"format-version"`
+	OrganizationName string                  `json:"organization-name"`
+	TakenAt          time.Time               `json:"taken-at"`
+	Workspaces       []Workspace             `json:"workspaces"`
+	Modules          []Module                `json:"modules"`
+	Providers        []Provider              `json:"providers"`
+	TFVersions       []TFVersion             `json:"tf-versions"`
+	RegistryModules  []PrivateRegistryModule `json:"registry-modules"`
+}
+
+// Snapshot collects the organization's workspaces, modules, providers, Terraform versions and private registry modules
+// into a Snapshot taken at the current time.
+func (c *Cartographer) Snapshot() (*Snapshot, error) {
+	snapshot := &Snapshot{
+		FormatVersion:    SnapshotFormatVersion,
+		OrganizationName: c.orgName,
+		TakenAt:          time.Now().UTC(),
+	}
+
+	var err error
+	if snapshot.Workspaces, err = c.Workspaces(nil); err != nil {
+		return nil, fmt.Errorf("snapshot workspaces: %w", err)
+	}
+	if snapshot.Modules, err = c.Modules(nil); err != nil {
+		return nil, fmt.Errorf("snapshot modules: %w", err)
+	}
+	if snapshot.Providers, err = c.Providers(nil); err != nil {
+		return nil, fmt.Errorf("snapshot providers: %w", err)
+	}
+	if snapshot.TFVersions, err = c.TFVersions(nil); err != nil {
+		return nil, fmt.Errorf("snapshot terraform versions: %w", err)
+	}
+	if snapshot.RegistryModules, err = c.PrivateRegistryModules(); err != nil {
+		return nil, fmt.Errorf("snapshot registry modules: %w", err)
+	}
+
+	return snapshot, nil
+}
+
+// Write serialises the snapshot as indented JSON.
+func (s *Snapshot) Write(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(s)
+}
+
+// Save writes the snapshot to the file at path. The file is written to a temporary file in the same directory first
+// and then renamed, so an existing snapshot is never left half written.
+func (s *Snapshot) Save(path string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := s.Write(tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// ReadSnapshot decodes a snapshot written by Snapshot.Write. It returns an error if the snapshot was written in a newer
+// format than this version of the library understands.
+func ReadSnapshot(r io.Reader) (*Snapshot, error) {
+	var snapshot Snapshot
+	if err := json.NewDecoder(r).Decode(&snapshot); err != nil {
+		return nil, err
+	}
+
+	if snapshot.FormatVersion < 1 || snapshot.FormatVersion > SnapshotFormatVersion {
+		return nil, fmt.Errorf("unsupported snapshot format version %d, expected 1 to %d", snapshot.FormatVersion, SnapshotFormatVersion)
+	}
+
+	return &snapshot, nil
+}
+
+// LoadSnapshot reads a snapshot from the file at path.
+func LoadSnapshot(path string) (*Snapshot, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ReadSnapshot(f)
+}
@@ -0,0 +1,442 @@
+package cartographer
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	Added ChangeKind = iota
+	Removed
+	Changed
+)
+
+// ChangeKind describes how an entity differs between two snapshots.
+type ChangeKind int
+
+func (k ChangeKind) String() string {
+	return [...]string{"added", "removed", "changed"}[k]
+}
+
+// MarshalText encodes the kind as its string form so that it is readable in JSON output.
+func (k ChangeKind) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+// FieldChange is a single attribute of an entity whose value differs between two snapshots.
+type FieldChange struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// EntityChange is an entity that was added, removed or changed between two snapshots. Key identifies the entity:
+// the workspace name, module or provider source, Terraform version, or registry module source.
+type EntityChange struct {
+	Kind   ChangeKind    `json:"kind"`
+	Key    string        `json:"key"`
+	Fields []FieldChange `json:"fields,omitempty"`
+}
+
+// VersionChange is a workspace that moved from one version of something to another between two snapshots. Subject is
+// the module or provider source, and is empty for Terraform version changes. A workspace can use several versions of a
+// module at once, for example through a submodule, in which case From and To list every version in use from newest
+// to oldest separated by commas.
+type VersionChange struct {
+	Workspace string `json:"workspace"`
+	Subject   string `json:"subject,omitempty"`
+	From      string `json:"from"`
+	To        string `json:"to"`
+}
+
+// Upgrade reports whether the change moved to a newer version. When several versions are listed the newest of each
+// are compared.
+func (v VersionChange) Upgrade() bool {
+	newest := func(versions string) string { return strings.SplitN(versions, ", ", 2)[0] }
+	return compareVersions(newest(v.To), newest(v.From)) > 0
+}
+
+// SnapshotDiff is the set of changes between two snapshots of the same organization.
+type SnapshotDiff struct {
+	From             time.Time       `json:"from"`
+	To               time.Time       `json:"to"`
+	Workspaces       []EntityChange  `json:"workspaces"`
+	Modules          []EntityChange  `json:"modules"`
+	Providers        []EntityChange  `json:"providers"`
+	TFVersions       []EntityChange  `json:"tf-versions"`
+	RegistryModules  []EntityChange  `json:"registry-modules"`
+	ModuleChanges    []VersionChange `json:"module-changes"`
+	ProviderChanges  []VersionChange `json:"provider-changes"`
+	TFVersionChanges []VersionChange `json:"tf-version-changes"`
+	// NewlyDrifted are the names of workspaces that are drifted in the newer snapshot but were not drifted, or did not
+	// exist, in the older one.
+	NewlyDrifted []string `json:"newly-drifted"`
+}
+
+// DiffSnapshots compares two snapshots and returns the changes needed to get from the older to the newer one.
+// Workspaces are matched by external ID so that renames are reported as changes rather than a removal and an addition.
+// All lists are sorted by key.
+func DiffSnapshots(from, to *Snapshot) *SnapshotDiff {
+	diff := &SnapshotDiff{From: from.TakenAt, To: to.TakenAt}
+
+	diff.Workspaces = diffEntities(workspaceFields(from.Workspaces), workspaceFields(to.Workspaces))
+	names := make(map[string]string)
+	for _, workspaces := range [][]Workspace{from.Workspaces, to.Workspaces} {
+		for _, w := range workspaces {
+			names[workspaceKey(w)] = w.WorkspaceName
+		}
+	}
+	for i := range diff.Workspaces {
+		diff.Workspaces[i].Key = names[diff.Workspaces[i].Key]
+	}
+	sort.SliceStable(diff.Workspaces, func(i, j int) bool { return diff.Workspaces[i].Key < diff.Workspaces[j].Key })
+
+	diff.Modules = diffEntities(moduleFields(from.Modules), moduleFields(to.Modules))
+	diff.Providers = diffEntities(providerFields(from.Providers), providerFields(to.Providers))
+	diff.TFVersions = diffEntities(tfVersionFields(from.TFVersions), tfVersionFields(to.TFVersions))
+	diff.RegistryModules = diffEntities(registryModuleFields(from.RegistryModules), registryModuleFields(to.RegistryModules))
+
+	fromKeys, toKeys := workspaceKeys(from.Workspaces), workspaceKeys(to.Workspaces)
+	diff.ModuleChanges = diffVersionUsage(moduleUsage(from.Modules, fromKeys), moduleUsage(to.Modules, toKeys), names)
+	diff.ProviderChanges = diffVersionUsage(providerUsage(from.Providers, fromKeys), providerUsage(to.Providers, toKeys), names)
+
+	before := make(map[string]Workspace)
+	for _, w := range from.Workspaces {
+		before[workspaceKey(w)] = w
+	}
+	for _, w := range to.Workspaces {
+		old, existed := before[workspaceKey(w)]
+		if w.Drifted && (!existed || !old.Drifted) {
+			diff.NewlyDrifted = append(diff.NewlyDrifted, w.WorkspaceName)
+		}
+		if existed && old.WorkspaceTerraformVersion != w.WorkspaceTerraformVersion {
+			diff.TFVersionChanges = append(diff.TFVersionChanges, VersionChange{
+				Workspace: w.WorkspaceName,
+				From:      old.WorkspaceTerraformVersion,
+				To:        w.WorkspaceTerraformVersion,
+			})
+		}
+	}
+	sort.Strings(diff.NewlyDrifted)
+	sort.Slice(diff.TFVersionChanges, func(i, j int) bool {
+		return diff.TFVersionChanges[i].Workspace < diff.TFVersionChanges[j].Workspace
+	})
+
+	return diff
+}
+
+// Empty reports whether the two snapshots were identical as far as the diff is concerned.
+func (d *SnapshotDiff) Empty() bool {
+	return len(d.Workspaces) == 0 && len(d.Modules) == 0 && len(d.Providers) == 0 && len(d.TFVersions) == 0 &&
+		len(d.RegistryModules) == 0 && len(d.ModuleChanges) == 0 && len(d.ProviderChanges) == 0 &&
+		len(d.TFVersionChanges) == 0 && len(d.NewlyDrifted) == 0
+}
+
+// Summary renders the diff as a short human-readable report suitable for a weekly review.
+func (d *SnapshotDiff) Summary() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Changes from %s to %s\n", d.From.Format(time.RFC3339), d.To.Format(time.RFC3339))
+	if d.Empty() {
+		b.WriteString("\nNo changes.\n")
+		return b.String()
+	}
+
+	writeEntityChanges(&b, "Workspaces", d.Workspaces)
+	writeEntityChanges(&b, "Modules", d.Modules)
+	writeEntityChanges(&b, "Providers", d.Providers)
+	writeEntityChanges(&b, "Terraform versions", d.TFVersions)
+	writeEntityChanges(&b, "Registry modules", d.RegistryModules)
+	writeVersionChanges(&b, "Module version changes", d.ModuleChanges)
+	writeVersionChanges(&b, "Provider version changes", d.ProviderChanges)
+	writeVersionChanges(&b, "Terraform version changes", d.TFVersionChanges)
+
+	if len(d.NewlyDrifted) > 0 {
+		fmt.Fprintf(&b, "\nNewly drifted (%d)\n", len(d.NewlyDrifted))
+		for _, name := range d.NewlyDrifted {
+			fmt.Fprintf(&b, "  ! %s\n", name)
+		}
+	}
+
+	return b.String()
+}
+
+// writeEntityChanges writes a section of the summary listing added, removed and changed entities.
+func writeEntityChanges(b *strings.Builder, title string, changes []EntityChange) {
+	if len(changes) == 0 {
+		return
+	}
+
+	var added, removed, changed int
+	for _, c := range changes {
+		switch c.Kind {
+		case Added:
+			added++
+		case Removed:
+			removed++
+		case Changed:
+			changed++
+		}
+	}
+	fmt.Fprintf(b, "\n%s (%d added, %d removed, %d changed)\n", title, added, removed, changed)
+
+	for _, c := range changes {
+		marker := [...]string{"+", "-", "~"}[c.Kind]
+		fmt.Fprintf(b, "  %s %s\n", marker, c.Key)
+		for _, f := range c.Fields {
+			fmt.Fprintf(b, "      %s: %q -> %q\n", f.Field, f.From, f.To)
+		}
+	}
+}
+
+// writeVersionChanges writes a section of the summary listing workspaces that changed version.
+func writeVersionChanges(b *strings.Builder, title string, changes []VersionChange) {
+	if len(changes) == 0 {
+		return
+	}
+
+	fmt.Fprintf(b, "\n%s (%d)\n", title, len(changes))
+	for _, c := range changes {
+		direction := "downgraded"
+		if c.Upgrade() {
+			direction = "upgraded"
+		}
+		subject := c.Workspace
+		if c.Subject != "" {
+			subject += " " + c.Subject
+		}
+		fmt.Fprintf(b, "  %s %s -> %s (%s)\n", subject, c.From, c.To, direction)
+	}
+}
+
+// diffEntities compares two sets of entities, each described by its fields, and returns the added, removed and changed
+// entities sorted by key. Changed fields are sorted by name.
+func diffEntities(from, to map[string]map[string]string) []EntityChange {
+	var changes []EntityChange
+	for key, fields := range to {
+		old, ok := from[key]
+		if !ok {
+			changes = append(changes, EntityChange{Kind: Added, Key: key})
+			continue
+		}
+
+		var fieldChanges []FieldChange
+		for field, value := range fields {
+			if old[field] != value {
+				fieldChanges = append(fieldChanges, FieldChange{Field: field, From: old[field], To: value})
+			}
+		}
+		for field, value := range old {
+			if _, ok := fields[field]; !ok {
+				fieldChanges = append(fieldChanges, FieldChange{Field: field, From: value})
+			}
+		}
+		if len(fieldChanges) > 0 {
+			sort.Slice(fieldChanges, func(i, j int) bool { return fieldChanges[i].Field < fieldChanges[j].Field })
+			changes = append(changes, EntityChange{Kind: Changed, Key: key, Fields: fieldChanges})
+		}
+	}
+	for key := range from {
+		if _, ok := to[key]; !ok {
+			changes = append(changes, EntityChange{Kind: Removed, Key: key})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Key < changes[j].Key })
+	return changes
+}
+
+// workspaceKey returns the key a workspace is matched on between snapshots: its external ID, or its name for
+// snapshots without one.
+func workspaceKey(w Workspace) string {
+	if w.ExternalId != "" {
+		return w.ExternalId
+	}
+	return w.WorkspaceName
+}
+
+// workspaceKeys maps workspace names to the key they are matched on, so that module and provider usage, which refer
+// to workspaces by name, survives renames.
+func workspaceKeys(workspaces []Workspace) map[string]string {
+	keys := make(map[string]string)
+	for _, w := range workspaces {
+		keys[w.WorkspaceName] = workspaceKey(w)
+	}
+	return keys
+}
+
+// usageKey returns the key to track a workspace's usage under: its workspace key when the workspace is in the
+// snapshot, or its name otherwise.
+func usageKey(name string, keys map[string]string) string {
+	if key, ok := keys[name]; ok {
+		return key
+	}
+	return name
+}
+
+// workspaceFields describes workspaces by the attributes tracked between snapshots, keyed by workspaceKey.
+func workspaceFields(workspaces []Workspace) map[string]map[string]string {
+	fields := make(map[string]map[string]string)
+	for _, w := range workspaces {
+		vcsRepo := ""
+		if w.VcsRepoIdentifier != nil {
+			vcsRepo = *w.VcsRepoIdentifier
+		}
+		fields[workspaceKey(w)] = map[string]string{
+			"name":              w.WorkspaceName,
+			"project":           w.ProjectName,
+			"terraform-version": w.WorkspaceTerraformVersion,
+			"drifted":           strconv.FormatBool(w.Drifted),
+			"checks-failed":     strconv.Itoa(w.ChecksFailed),
+			"current-run":       w.CurrentRunStatus,
+			"vcs-repo":          vcsRepo,
+			"module-count":      strconv.Itoa(w.ModuleCount),
+			"provider-count":    strconv.Itoa(w.ProviderCount),
+		}
+	}
+
+	return fields
+}
+
+// moduleFields describes modules by the versions in use and the number of workspaces using them, keyed by normalized
+// source.
+func moduleFields(modules []Module) map[string]map[string]string {
+	versions := make(map[string][]string)
+	counts := make(map[string]int)
+	for _, m := range modules {
+		source := normalizeModuleSource(m.Source)
+		versions[source] = appendUnique(versions[source], m.Version)
+		counts[source] += m.WorkspaceCount
+	}
+	return versionedFields(versions, counts)
+}
+
+// providerFields describes providers by the versions in use and the number of workspaces using them, keyed by source.
+func providerFields(providers []Provider) map[string]map[string]string {
+	versions := make(map[string][]string)
+	counts := make(map[string]int)
+	for _, p := range providers {
+		source := strings.ToLower(p.Source)
+		versions[source] = appendUnique(versions[source], p.Version)
+		counts[source] += p.WorkspaceCount
+	}
+	return versionedFields(versions, counts)
+}
+
+// versionedFields builds the fields of modules and providers from the versions in use and workspace counts.
+func versionedFields(versions map[string][]string, counts map[string]int) map[string]map[string]string {
+	fields := make(map[string]map[string]string)
+	for source, vs := range versions {
+		sort.Slice(vs, func(i, j int) bool { return compareVersions(vs[i], vs[j]) > 0 })
+		fields[source] = map[string]string{
+			"versions":        strings.Join(vs, ", "),
+			"workspace-count": strconv.Itoa(counts[source]),
+		}
+	}
+	return fields
+}
+
+// tfVersionFields describes Terraform versions by the number of workspaces using them.
+func tfVersionFields(versions []TFVersion) map[string]map[string]string {
+	fields := make(map[string]map[string]string)
+	for _, v := range versions {
+		fields[v.Version] = map[string]string{"workspace-count": strconv.Itoa(v.WorkspaceCount)}
+	}
+	return fields
+}
+
+// registryModuleFields describes private registry modules by their status and latest version, keyed by source.
+func registryModuleFields(modules []PrivateRegistryModule) map[string]map[string]string {
+	fields := make(map[string]map[string]string)
+	for _, m := range modules {
+		fields[m.Source()] = map[string]string{
+			"status":         m.Status,
+			"latest-version": m.LatestVersion,
+			"version-count":  strconv.Itoa(len(m.Versions)),
+		}
+	}
+	return fields
+}
+
+// moduleUsage maps each module source to the set of versions used by each workspace, keyed by usageKey. Sources are
+// normalized, so a workspace calling a module and one of its submodules at different versions uses both.
+func moduleUsage(modules []Module, keys map[string]string) map[string]map[string]map[string]bool {
+	usage := make(map[string]map[string]map[string]bool)
+	for _, m := range modules {
+		addVersionUsage(usage, normalizeModuleSource(m.Source), m.Version, m.WorkspaceNames(), keys)
+	}
+	return usage
+}
+
+// providerUsage maps each provider source to the set of versions used by each workspace, keyed by usageKey.
+func providerUsage(providers []Provider, keys map[string]string) map[string]map[string]map[string]bool {
+	usage := make(map[string]map[string]map[string]bool)
+	for _, p := range providers {
+		addVersionUsage(usage, strings.ToLower(p.Source), p.Version, p.WorkspaceNames(), keys)
+	}
+	return usage
+}
+
+// addVersionUsage records that the named workspaces use a version of a source.
+func addVersionUsage(usage map[string]map[string]map[string]bool, source, version string, workspaces []string, keys map[string]string) {
+	if usage[source] == nil {
+		usage[source] = make(map[string]map[string]bool)
+	}
+	for _, w := range workspaces {
+		key := usageKey(w, keys)
+		if usage[source][key] == nil {
+			usage[source][key] = make(map[string]bool)
+		}
+		usage[source][key][version] = true
+	}
+}
+
+// versionList returns a set of versions sorted from newest to oldest and separated by commas.
+func versionList(versions map[string]bool) string {
+	var list []string
+	for v := range versions {
+		list = append(list, v)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if c := compareVersions(list[i], list[j]); c != 0 {
+			return c > 0
+		}
+		return list[i] < list[j]
+	})
+	return strings.Join(list, ", ")
+}
+
+// diffVersionUsage returns the workspaces that use a different set of versions of a module or provider in the newer
+// snapshot, sorted by workspace name and then subject. Workspaces that started or stopped using it are not version
+// changes.
+func diffVersionUsage(from, to map[string]map[string]map[string]bool, names map[string]string) []VersionChange {
+	var changes []VersionChange
+	for source, workspaces := range to {
+		for key, versions := range workspaces {
+			oldVersions, ok := from[source][key]
+			if !ok {
+				continue
+			}
+			old, current := versionList(oldVersions), versionList(versions)
+			if old == current {
+				continue
+			}
+
+			name, ok := names[key]
+			if !ok {
+				name = key
+			}
+			changes = append(changes, VersionChange{Workspace: name, Subject: source, From: old, To: current})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Workspace != changes[j].Workspace {
+			return changes[i].Workspace < changes[j].Workspace
+		}
+		return changes[i].Subject < changes[j].Subject
+	})
+	return changes
+}
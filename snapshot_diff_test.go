@@ -0,0 +1,153 @@
+package cartographer
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDiffSnapshots(t *testing.T) {
+	from := &Snapshot{
+		TakenAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Workspaces: []Workspace{
+			{ExternalId: "ws-1", WorkspaceName: "network", WorkspaceTerraformVersion: "1.5.7"},
+			{ExternalId: "ws-2", WorkspaceName: "app", WorkspaceTerraformVersion: "1.6.0"},
+			{ExternalId: "ws-3", WorkspaceName: "legacy", Drifted: true},
+		},
+		Modules: []Module{
+			{Source: "app.terraform.io/myOrg/vpc/aws", Version: "1.0.0", WorkspaceCount: 2, Workspaces: "network,app"},
+		},
+		Providers: []Provider{
+			{Source: "hashicorp/aws", Version: "4.67.0", WorkspaceCount: 2, Workspaces: "network,app"},
+		},
+		TFVersions: []TFVersion{
+			{Version: "1.5.7", WorkspaceCount: 1},
+			{Version: "1.6.0", WorkspaceCount: 1},
+		},
+		RegistryModules: []PrivateRegistryModule{
+			{Namespace: "myOrg", Name: "vpc", Provider: "aws", RegistryName: "private", Status: "setup_complete", LatestVersion: "1.0.0"},
+		},
+	}
+	to := &Snapshot{
+		TakenAt: time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC),
+		Workspaces: []Workspace{
+			{ExternalId: "ws-1", WorkspaceName: "network-prod", WorkspaceTerraformVersion: "1.6.0", Drifted: true},
+			{ExternalId: "ws-2", WorkspaceName: "app", WorkspaceTerraformVersion: "1.6.0"},
+			{ExternalId: "ws-4", WorkspaceName: "dns", WorkspaceTerraformVersion: "1.6.0"},
+		},
+		Modules: []Module{
+			{Source: "app.terraform.io/myOrg/vpc/aws", Version: "1.1.0", WorkspaceCount: 1, Workspaces: "network-prod"},
+			{Source: "app.terraform.io/myOrg/vpc/aws", Version: "1.0.0", WorkspaceCount: 1, Workspaces: "app"},
+		},
+		Providers: []Provider{
+			{Source: "hashicorp/aws", Version: "5.0.0", WorkspaceCount: 1, Workspaces: "app"},
+			{Source: "hashicorp/aws", Version: "4.67.0", WorkspaceCount: 1, Workspaces: "network-prod"},
+		},
+		TFVersions: []TFVersion{
+			{Version: "1.6.0", WorkspaceCount: 3},
+		},
+		RegistryModules: []PrivateRegistryModule{
+			{Namespace: "myOrg", Name: "vpc", Provider: "aws", RegistryName: "private", Status: "setup_complete", LatestVersion: "1.1.0"},
+		},
+	}
+
+	diff := DiffSnapshots(from, to)
+
+	expectedWorkspaces := []EntityChange{
+		{Kind: Added, Key: "dns"},
+		{Kind: Removed, Key: "legacy"},
+		{Kind: Changed, Key: "network-prod", Fields: []FieldChange{
+			{Field: "drifted", From: "false", To: "true"},
+			{Field: "name", From: "network", To: "network-prod"},
+			{Field: "terraform-version", From: "1.5.7", To: "1.6.0"},
+		}},
+	}
+	if !reflect.DeepEqual(diff.Workspaces, expectedWorkspaces) {
+		t.Errorf("DiffSnapshots() returned workspaces %+v, expected %+v", diff.Workspaces, expectedWorkspaces)
+	}
+
+	expectedModules := []EntityChange{
+		{Kind: Changed, Key: "app.terraform.io/myorg/vpc/aws", Fields: []FieldChange{
+			{Field: "versions", From: "1.0.0", To: "1.1.0, 1.0.0"},
+		}},
+	}
+	if !reflect.DeepEqual(diff.Modules, expectedModules) {
+		t.Errorf("DiffSnapshots() returned modules %+v, expected %+v", diff.Modules, expectedModules)
+	}
+
+	expectedModuleChanges := []VersionChange{
+		{Workspace: "network-prod", Subject: "app.terraform.io/myorg/vpc/aws", From: "1.0.0", To: "1.1.0"},
+	}
+	if !reflect.DeepEqual(diff.ModuleChanges, expectedModuleChanges) {
+		t.Errorf("DiffSnapshots() returned module changes %+v, expected %+v", diff.ModuleChanges, expectedModuleChanges)
+	}
+
+	expectedProviderChanges := []VersionChange{
+		{Workspace: "app", Subject: "hashicorp/aws", From: "4.67.0", To: "5.0.0"},
+	}
+	if !reflect.DeepEqual(diff.ProviderChanges, expectedProviderChanges) {
+		t.Errorf("DiffSnapshots() returned provider changes %+v, expected %+v", diff.ProviderChanges, expectedProviderChanges)
+	}
+
+	expectedTFChanges := []VersionChange{{Workspace: "network-prod", From: "1.5.7", To: "1.6.0"}}
+	if !reflect.DeepEqual(diff.TFVersionChanges, expectedTFChanges) {
+		t.Errorf("DiffSnapshots() returned terraform version changes %+v, expected %+v", diff.TFVersionChanges, expectedTFChanges)
+	}
+
+	if !reflect.DeepEqual(diff.NewlyDrifted, []string{"network-prod"}) {
+		t.Errorf("DiffSnapshots() returned newly drifted %v, expected [network-prod]", diff.NewlyDrifted)
+	}
+
+	if len(diff.TFVersions) != 2 || diff.TFVersions[0].Kind != Removed || diff.TFVersions[1].Kind != Changed {
+		t.Errorf("DiffSnapshots() returned terraform versions %+v", diff.TFVersions)
+	}
+	if len(diff.RegistryModules) != 1 || diff.RegistryModules[0].Fields[0].To != "1.1.0" {
+		t.Errorf("DiffSnapshots() returned registry modules %+v", diff.RegistryModules)
+	}
+
+	summary := diff.Summary()
+	for _, line := range []string{
+		"Changes from 2024-01-01T00:00:00Z to 2024-01-08T00:00:00Z",
+		"Workspaces (1 added, 1 removed, 1 changed)",
+		"  + dns",
+		"  - legacy",
+		"      name: \"network\" -> \"network-prod\"",
+		"  app hashicorp/aws 4.67.0 -> 5.0.0 (upgraded)",
+		"Newly drifted (1)",
+	} {
+		if !strings.Contains(summary, line+"\n") {
+			t.Errorf("Summary() is missing %q:\n%s", line, summary)
+		}
+	}
+
+	if same := DiffSnapshots(to, to); !same.Empty() || !strings.Contains(same.Summary(), "No changes.") {
+		t.Errorf("DiffSnapshots() of identical snapshots returned %+v", same)
+	}
+}
+
+func TestDiffSnapshotsSubmoduleVersions(t *testing.T) {
+	workspaces := []Workspace{{WorkspaceName: "app", ExternalId: "ws-1"}}
+	root := Module{Name: "vpc", Source: "app.terraform.io/myorg/vpc/aws", Version: "1.0.0", Workspaces: "app"}
+	submodule := Module{Name: "subnets", Source: "app.terraform.io/myorg/vpc/aws//modules/subnets", Version: "1.2.0", Workspaces: "app"}
+
+	// The Explorer does not guarantee row order, so the same usage in a different order is not a change.
+	from := &Snapshot{Workspaces: workspaces, Modules: []Module{root, submodule}}
+	to := &Snapshot{Workspaces: workspaces, Modules: []Module{submodule, root}}
+	if diff := DiffSnapshots(from, to); len(diff.ModuleChanges) != 0 {
+		t.Errorf("DiffSnapshots() with reordered rows returned module changes %+v, expected none", diff.ModuleChanges)
+	}
+
+	root.Version = "1.3.0"
+	to = &Snapshot{Workspaces: workspaces, Modules: []Module{submodule, root}}
+	diff := DiffSnapshots(from, to)
+	expected := []VersionChange{
+		{Workspace: "app", Subject: "app.terraform.io/myorg/vpc/aws", From: "1.2.0, 1.0.0", To: "1.3.0, 1.2.0"},
+	}
+	if !reflect.DeepEqual(diff.ModuleChanges, expected) {
+		t.Errorf("DiffSnapshots() returned module changes %+v, expected %+v", diff.ModuleChanges, expected)
+	}
+	if !diff.ModuleChanges[0].Upgrade() {
+		t.Errorf("Upgrade() of %+v returned false, expected true", diff.ModuleChanges[0])
+	}
+}
@@ -0,0 +1,76 @@
+package cartographer
+
+import (
+	"io"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSnapshot(t *testing.T) {
+	emptyPage := `"links": {"self": "test", "first": "test", "last": "test", "prev": null, "next": null},
+		"meta": {"pagination": {"current-page": 1, "page-size": 100, "next-page": null, "prev-page": null, "total-pages": 1, "total-count": 1}}`
+	responses := map[string]string{
+		"workspaces":       `{"data": [{"attributes": {"workspace-name": "ws", "modules": "vpc:1.0.0", "workspace-updated-at": "2024-01-01T00:00:00Z"}, "id": "1", "type": "t"}], ` + emptyPage + `}`,
+		"modules":          `{"data": [{"attributes": {"name": "vpc", "version": "1.0.0", "workspaces": "ws"}, "id": "2", "type": "t"}], ` + emptyPage + `}`,
+		"providers":        `{"data": [{"attributes": {"name": "aws", "version": "5.0.0", "workspaces": "ws"}, "id": "3", "type": "t"}], ` + emptyPage + `}`,
+		"tf_versions":      `{"data": [{"attributes": {"version": "1.6.0", "workspace-count": 1, "workspaces": "ws"}, "id": "4", "type": "t"}], ` + emptyPage + `}`,
+		"registry-modules": `{"data": [{"id": "mod-1", "type": "registry-modules", "attributes": {"name": "vpc", "version-statuses": [{"version": "1.0.0", "status": "ok"}]}}], ` + emptyPage + `}`,
+	}
+
+	mockClient := &MockClient{
+		MockDo: func(req *http.Request) (*http.Response, error) {
+			key := req.URL.Query().Get("type")
+			if strings.HasSuffix(req.URL.Path, "/registry-modules") {
+				key = "registry-modules"
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(responses[key])),
+			}, nil
+		},
+	}
+
+	c := &Cartographer{
+		client:  mockClient,
+		orgName: "test",
+		token:   "test",
+	}
+
+	snapshot, err := c.Snapshot()
+	if err != nil {
+		t.Fatalf("Snapshot() returned an error: %v", err)
+	}
+
+	if snapshot.OrganizationName != "test" || snapshot.FormatVersion != SnapshotFormatVersion || snapshot.TakenAt.IsZero() {
+		t.Errorf("Snapshot() returned header %+v", snapshot)
+	}
+	if len(snapshot.Workspaces) != 1 || len(snapshot.Modules) != 1 || len(snapshot.Providers) != 1 ||
+		len(snapshot.TFVersions) != 1 || len(snapshot.RegistryModules) != 1 {
+		t.Fatalf("Snapshot() returned %+v, expected one of each entity", snapshot)
+	}
+
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	if err := snapshot.Save(path); err != nil {
+		t.Fatalf("Save() returned an error: %v", err)
+	}
+
+	loaded, err := LoadSnapshot(path)
+	if err != nil {
+		t.Fatalf("LoadSnapshot() returned an error: %v", err)
+	}
+
+	if !reflect.DeepEqual(loaded, snapshot) {
+		t.Errorf("LoadSnapshot() returned %+v, expected %+v", loaded, snapshot)
+	}
+}
+
+func TestReadSnapshotUnsupportedVersion(t *testing.T) {
+	for _, input := range []string{`{"format-version": 99}`, `{}`} {
+		if _, err := ReadSnapshot(strings.NewReader(input)); err == nil {
+			t.Errorf("ReadSnapshot(%s) expected an error", input)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package cartographer
+
+import (
+	"sort"
+	"time"
+)
+
+const (
+	NeverApplied StalenessCategory = iota
+	ApplyStale
+	UpdateStale
+)
+
+// StalenessCategory describes why a workspace is considered stale.
+type StalenessCategory int
+
+func (s StalenessCategory) String() string {
+	return [...]string{"never-applied", "apply-stale", "update-stale"}[s]
+}
+
+// MarshalText encodes the category as its string form so that it is readable in JSON output.
+func (s StalenessCategory) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// StalenessOptions configures stale workspace detection. A zero threshold disables the corresponding check.
+type StalenessOptions struct {
+	// MaxApplyAge flags workspaces whose current run was applied longer ago than this.
+	MaxApplyAge time.Duration
+	// MaxUpdateAge flags workspaces that have not been updated for longer than this.
+	MaxUpdateAge time.Duration
+	// IncludeNeverApplied flags workspaces that have never had a run applied.
+	IncludeNeverApplied bool
+	// Now returns the time ages are computed against. It defaults to time.Now.
+	Now func() time.Time
+}
+
+// now returns the current time according to the options.
+func (o StalenessOptions) now() time.Time {
+	if o.Now == nil {
+		return time.Now()
+	}
+	return o.Now()
+}
+
+// StaleWorkspaces finds the stale workspaces in the organization. Each enabled check is sent to the Explorer as a date
+// or emptiness filter so that only candidate workspaces are fetched, and the results are merged and categorised by
+// FindStaleWorkspaces.
+func (c *Cartographer) StaleWorkspaces(opts StalenessOptions) (*StalenessReport, error) {
+	now := opts.now()
+	opts.Now = func() time.Time { return now }
+
+	var queries [][]WorkspaceFilter
+	if opts.MaxApplyAge > 0 {
+		queries = append(queries, []WorkspaceFilter{{
+			Type:     WorkspaceCurrentRunAppliedAt,
+			Operator: IsBefore,
+			Value:    now.Add(-opts.MaxApplyAge).UTC().Format(time.RFC3339),
+		}})
+	}
+	if opts.MaxUpdateAge > 0 {
+		queries = append(queries, []WorkspaceFilter{{
+			Type:     WorkspaceUpdatedAt,
+			Operator: IsBefore,
+			Value:    now.Add(-opts.MaxUpdateAge).UTC().Format(time.RFC3339),
+		}})
+	}
+	if opts.IncludeNeverApplied {
+		queries = append(queries, []WorkspaceFilter{{
+			Type:     WorkspaceCurrentRunAppliedAt,
+			Operator: IsEmpty,
+		}})
+	}
+
+	seen := make(map[string]bool)
+	var candidates []Workspace
+	for _, filters := range queries {
+		workspaces, err := c.Workspaces(filters)
+		if err != nil {
+			return nil, err
+		}
+		for _, w := range workspaces {
+			if seen[w.ExternalId] {
+				continue
+			}
+			seen[w.ExternalId] = true
+			candidates = append(candidates, w)
+		}
+	}
+
+	return FindStaleWorkspaces(candidates, opts), nil
+}
+
+// FindStaleWorkspaces categorises the given workspaces according to the options. A workspace can appear in several
+// categories. Every list is sorted with the longest idle workspace first.
+func FindStaleWorkspaces(workspaces []Workspace, opts StalenessOptions) *StalenessReport {
+	now := opts.now()
+	report := &StalenessReport{GeneratedAt: now}
+
+	for _, w := range workspaces {
+		stale := StaleWorkspace{
+			Workspace:  w,
+			UpdatedAge: now.Sub(w.WorkspaceUpdatedAt),
+		}
+		if w.CurrentRunAppliedAt != nil {
+			age := now.Sub(*w.CurrentRunAppliedAt)
+			stale.AppliedAge = &age
+		}
+
+		if opts.IncludeNeverApplied && stale.AppliedAge == nil {
+			stale.Categories = append(stale.Categories, NeverApplied)
+		}
+		if opts.MaxApplyAge > 0 && stale.AppliedAge != nil && *stale.AppliedAge > opts.MaxApplyAge {
+			stale.Categories = append(stale.Categories, ApplyStale)
+		}
+		if opts.MaxUpdateAge > 0 && stale.UpdatedAge > opts.MaxUpdateAge {
+			stale.Categories = append(stale.Categories, UpdateStale)
+		}
+
+		if len(stale.Categories) == 0 {
+			continue
+		}
+
+		report.Workspaces = append(report.Workspaces, stale)
+		for _, category := range stale.Categories {
+			switch category {
+			case NeverApplied:
+				report.NeverApplied = append(report.NeverApplied, stale)
+			case ApplyStale:
+				report.ApplyStale = append(report.ApplyStale, stale)
+			case UpdateStale:
+				report.UpdateStale = append(report.UpdateStale, stale)
+			}
+		}
+	}
+
+	for _, list := range [][]StaleWorkspace{report.Workspaces, report.NeverApplied, report.ApplyStale, report.UpdateStale} {
+		sortStaleWorkspaces(list)
+	}
+
+	return report
+}
+
+// StalenessReport lists stale workspaces, both all together and by category.
+type StalenessReport struct {
+	GeneratedAt  time.Time        `json:"generated-at"`
+	Workspaces   []StaleWorkspace `json:"workspaces"`
+	NeverApplied []StaleWorkspace `json:"never-applied"`
+	ApplyStale   []StaleWorkspace `json:"apply-stale"`
+	UpdateStale  []StaleWorkspace `json:"update-stale"`
+}
+
+// StaleWorkspace represents a stale workspace along with how long ago it was last applied and updated. AppliedAge is
+// nil for workspaces that have never been applied.
+type StaleWorkspace struct {
+	Workspace  Workspace           `json:"workspace"`
+	Categories []StalenessCategory `json:"categories"`
+	AppliedAge *time.Duration      `json:"applied-age"`
+	UpdatedAge time.Duration       `json:"updated-age"`
+}
+
+// sortStaleWorkspaces sorts workspaces that were never applied first, then by apply age and update age, oldest first.
+func sortStaleWorkspaces(workspaces []StaleWorkspace) {
+	sort.SliceStable(workspaces, func(i, j int) bool {
+		a, b := workspaces[i], workspaces[j]
+		if (a.AppliedAge == nil) != (b.AppliedAge == nil) {
+			return a.AppliedAge == nil
+		}
+		if a.AppliedAge != nil && *a.AppliedAge != *b.AppliedAge {
+			return *a.AppliedAge > *b.AppliedAge
+		}
+		if a.UpdatedAge != b.UpdatedAge {
+			return a.UpdatedAge > b.UpdatedAge
+		}
+		return a.Workspace.WorkspaceName < b.Workspace.WorkspaceName
+	})
+}
@@ -0,0 +1,104 @@
+package cartographer
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFindStaleWorkspaces(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	applied := func(ago time.Duration) *time.Time {
+		at := now.Add(-ago)
+		return &at
+	}
+
+	workspaces := []Workspace{
+		{WorkspaceName: "fresh", CurrentRunAppliedAt: applied(day), WorkspaceUpdatedAt: now.Add(-day)},
+		{WorkspaceName: "never", WorkspaceUpdatedAt: now.Add(-10 * day)},
+		{WorkspaceName: "old-apply", CurrentRunAppliedAt: applied(200 * day), WorkspaceUpdatedAt: now.Add(-5 * day)},
+		{WorkspaceName: "ancient", CurrentRunAppliedAt: applied(400 * day), WorkspaceUpdatedAt: now.Add(-400 * day)},
+	}
+
+	report := FindStaleWorkspaces(workspaces, StalenessOptions{
+		MaxApplyAge:         90 * day,
+		MaxUpdateAge:        365 * day,
+		IncludeNeverApplied: true,
+		Now:                 func() time.Time { return now },
+	})
+
+	var names []string
+	for _, w := range report.Workspaces {
+		names = append(names, w.Workspace.WorkspaceName)
+	}
+	if strings.Join(names, ",") != "never,ancient,old-apply" {
+		t.Errorf("FindStaleWorkspaces() returned %v, expected never,ancient,old-apply", names)
+	}
+
+	if len(report.NeverApplied) != 1 || report.NeverApplied[0].AppliedAge != nil {
+		t.Errorf("FindStaleWorkspaces() returned never applied %+v", report.NeverApplied)
+	}
+	if len(report.ApplyStale) != 2 || *report.ApplyStale[0].AppliedAge != 400*day {
+		t.Errorf("FindStaleWorkspaces() returned apply stale %+v", report.ApplyStale)
+	}
+	if len(report.UpdateStale) != 1 || report.UpdateStale[0].UpdatedAge != 400*day {
+		t.Errorf("FindStaleWorkspaces() returned update stale %+v", report.UpdateStale)
+	}
+	if len(report.Workspaces[1].Categories) != 2 {
+		t.Errorf("FindStaleWorkspaces() returned categories %v for ancient, expected 2", report.Workspaces[1].Categories)
+	}
+}
+
+func TestStaleWorkspaces(t *testing.T) {
+	jsonResponse := `{
+		"data": [
+			{"attributes": {"workspace-name": "never", "external-id": "ws-1", "workspace-updated-at": "2024-05-01T00:00:00Z"}, "id": "1", "type": "explorer-workspace-row"}
+		],
+		"links": {"self": "test", "first": "test", "last": "test", "prev": null, "next": null},
+		"meta": {"pagination": {"current-page": 1, "page-size": 100, "next-page": null, "prev-page": null, "total-pages": 1, "total-count": 1}}
+	}`
+
+	var queries []string
+	mockClient := &MockClient{
+		MockDo: func(req *http.Request) (*http.Response, error) {
+			queries = append(queries, req.URL.RawQuery)
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(jsonResponse)),
+			}, nil
+		},
+	}
+
+	c := &Cartographer{
+		client:  mockClient,
+		orgName: "test",
+		token:   "test",
+	}
+
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	report, err := c.StaleWorkspaces(StalenessOptions{
+		MaxApplyAge:         30 * 24 * time.Hour,
+		IncludeNeverApplied: true,
+		Now:                 func() time.Time { return now },
+	})
+	if err != nil {
+		t.Fatalf("StaleWorkspaces() returned an error: %v", err)
+	}
+
+	if len(queries) != 2 {
+		t.Fatalf("StaleWorkspaces() sent %v queries, expected 2", len(queries))
+	}
+	if !strings.Contains(queries[0], "current-run-applied-at%5D%5Bis-before%5D%5B0%5D=2024-05-02T00%3A00%3A00Z") {
+		t.Errorf("StaleWorkspaces() sent query %v, expected an is-before filter", queries[0])
+	}
+	if !strings.Contains(queries[1], "current-run-applied-at%5D%5Bis-empty%5D") {
+		t.Errorf("StaleWorkspaces() sent query %v, expected an is-empty filter", queries[1])
+	}
+
+	if len(report.Workspaces) != 1 || len(report.NeverApplied) != 1 {
+		t.Errorf("StaleWorkspaces() returned %+v, expected a single never applied workspace", report.Workspaces)
+	}
+}
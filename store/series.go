@@ -0,0 +1,141 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// dailySnapshots selects the last snapshot of an organization taken on each day in a time range. Its parameters are
+// the organization name and the start and end of the range.
+const dailySnapshots = `
+WITH daily AS (
+	SELECT substr(taken_at, 1, 10) AS day, MAX(taken_at) AS taken_at
+	FROM snapshots
+	WHERE organization_name = ?1 AND taken_at >= ?2 AND taken_at < ?3
+	GROUP BY day
+)
+SELECT daily.day, snapshots.id AS snapshot_id
+FROM daily
+JOIN snapshots ON snapshots.organization_name = ?1 AND snapshots.taken_at = daily.taken_at
+`
+
+// VersionPoint is the number of workspaces using a version of something on a day.
+type VersionPoint struct {
+	Day            time.Time `json:"day"`
+	Version        string    `json:"version"`
+	WorkspaceCount int       `json:"workspace-count"`
+}
+
+// DriftPoint is the number of workspaces, and how many of them were drifted, on a day.
+type DriftPoint struct {
+	Day            time.Time `json:"day"`
+	WorkspaceCount int       `json:"workspace-count"`
+	DriftedCount   int       `json:"drifted-count"`
+}
+
+// TFVersionSeries returns the number of workspaces on each Terraform version per day, using the last snapshot of each
+// day between from and to. A zero from or to leaves that end of the range open. Points are sorted by day and version.
+func (s *Store) TFVersionSeries(org string, from, to time.Time) ([]VersionPoint, error) {
+	query := "WITH d AS (" + dailySnapshots + `)
+		SELECT d.day, ws.terraform_version, COUNT(*)
+		FROM d JOIN workspace_states ws ON ws.snapshot_id = d.snapshot_id
+		GROUP BY d.day, ws.terraform_version
+		ORDER BY d.day, ws.terraform_version`
+
+	return s.versionSeries(query, rangeArgs(org, from, to)...)
+}
+
+// ModuleVersionSeries returns the number of workspaces using each version of the module with the given source per day,
+// using the last snapshot of each day between from and to. Points are sorted by day and version.
+func (s *Store) ModuleVersionSeries(org, source string, from, to time.Time) ([]VersionPoint, error) {
+	return s.usageSeries("workspace_modules", "modules", "module_id", org, source, from, to)
+}
+
+// ProviderVersionSeries returns the number of workspaces using each version of the provider with the given source per
+// day, using the last snapshot of each day between from and to. Points are sorted by day and version.
+func (s *Store) ProviderVersionSeries(org, source string, from, to time.Time) ([]VersionPoint, error) {
+	return s.usageSeries("workspace_providers", "providers", "provider_id", org, source, from, to)
+}
+
+// usageSeries returns the daily version usage of a module or provider from its usage table.
+func (s *Store) usageSeries(table, entities, column, org, source string, from, to time.Time) ([]VersionPoint, error) {
+	query := "WITH d AS (" + dailySnapshots + ")" + fmt.Sprintf(`
+		SELECT d.day, u.version, COUNT(DISTINCT u.workspace_id)
+		FROM d
+		JOIN %[1]s u ON u.snapshot_id = d.snapshot_id
+		JOIN %[2]s e ON e.id = u.%[3]s
+		WHERE e.source = ?4
+		GROUP BY d.day, u.version
+		ORDER BY d.day, u.version`, table, entities, column)
+
+	return s.versionSeries(query, append(rangeArgs(org, from, to), source)...)
+}
+
+// versionSeries runs a query returning day, version and workspace count rows.
+func (s *Store) versionSeries(query string, args ...interface{}) ([]VersionPoint, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var points []VersionPoint
+	for rows.Next() {
+		var p VersionPoint
+		var day string
+		if err := rows.Scan(&day, &p.Version, &p.WorkspaceCount); err != nil {
+			return nil, err
+		}
+		if p.Day, err = time.Parse(dayLayout, day); err != nil {
+			return nil, err
+		}
+		points = append(points, p)
+	}
+	return points, rows.Err()
+}
+
+// DriftSeries returns the number of workspaces and drifted workspaces per day, using the last snapshot of each day
+// between from and to. Points are sorted by day.
+func (s *Store) DriftSeries(org string, from, to time.Time) ([]DriftPoint, error) {
+	query := "WITH d AS (" + dailySnapshots + `)
+		SELECT d.day, COUNT(*), SUM(ws.drifted)
+		FROM d JOIN workspace_states ws ON ws.snapshot_id = d.snapshot_id
+		GROUP BY d.day
+		ORDER BY d.day`
+
+	rows, err := s.db.Query(query, rangeArgs(org, from, to)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var points []DriftPoint
+	for rows.Next() {
+		var p DriftPoint
+		var day string
+		var drifted sql.NullInt64
+		if err := rows.Scan(&day, &p.WorkspaceCount, &drifted); err != nil {
+			return nil, err
+		}
+		if p.Day, err = time.Parse(dayLayout, day); err != nil {
+			return nil, err
+		}
+		p.DriftedCount = int(drifted.Int64)
+		points = append(points, p)
+	}
+	return points, rows.Err()
+}
+
+// rangeArgs returns the organization and time range parameters of dailySnapshots. A zero end leaves the range open.
+func rangeArgs(org string, from, to time.Time) []interface{} {
+	end := "9999"
+	if !to.IsZero() {
+		end = to.UTC().Format(timeLayout)
+	}
+	start := ""
+	if !from.IsZero() {
+		start = from.UTC().Format(timeLayout)
+	}
+	return []interface{}{org, start, end}
+}
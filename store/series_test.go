@@ -0,0 +1,82 @@
+package store
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func day(d int) time.Time {
+	return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestSeries(t *testing.T) {
+	s := openTestStore(t, filepath.Join(t.TempDir(), "history.db"))
+
+	for _, snapshot := range []struct {
+		takenAt time.Time
+		version string
+		drifted bool
+	}{
+		{day(1).Add(9 * time.Hour), "1.5.7", false},
+		// Only the last snapshot of a day counts.
+		{day(2).Add(9 * time.Hour), "1.5.7", false},
+		{day(2).Add(17 * time.Hour), "1.6.0", true},
+		{day(3).Add(9 * time.Hour), "1.6.0", false},
+	} {
+		if _, err := s.Append(testSnapshot(snapshot.takenAt, snapshot.version, snapshot.drifted)); err != nil {
+			t.Fatalf("Append() returned an error: %v", err)
+		}
+	}
+
+	tfVersions, err := s.TFVersionSeries("test", day(1), day(3))
+	if err != nil {
+		t.Fatalf("TFVersionSeries() returned an error: %v", err)
+	}
+	expected := []VersionPoint{
+		{Day: day(1), Version: "1.5.7", WorkspaceCount: 1},
+		{Day: day(1), Version: "1.6.0", WorkspaceCount: 1},
+		{Day: day(2), Version: "1.6.0", WorkspaceCount: 2},
+	}
+	if !reflect.DeepEqual(tfVersions, expected) {
+		t.Errorf("TFVersionSeries() returned %+v, expected %+v", tfVersions, expected)
+	}
+
+	drift, err := s.DriftSeries("test", time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatalf("DriftSeries() returned an error: %v", err)
+	}
+	expectedDrift := []DriftPoint{
+		{Day: day(1), WorkspaceCount: 2},
+		{Day: day(2), WorkspaceCount: 2, DriftedCount: 1},
+		{Day: day(3), WorkspaceCount: 2},
+	}
+	if !reflect.DeepEqual(drift, expectedDrift) {
+		t.Errorf("DriftSeries() returned %+v, expected %+v", drift, expectedDrift)
+	}
+
+	providers, err := s.ProviderVersionSeries("test", "hashicorp/aws", day(3), time.Time{})
+	if err != nil {
+		t.Fatalf("ProviderVersionSeries() returned an error: %v", err)
+	}
+	expectedProviders := []VersionPoint{
+		{Day: day(3), Version: "4.67.0", WorkspaceCount: 1},
+		{Day: day(3), Version: "5.0.0", WorkspaceCount: 1},
+	}
+	if !reflect.DeepEqual(providers, expectedProviders) {
+		t.Errorf("ProviderVersionSeries() returned %+v, expected %+v", providers, expectedProviders)
+	}
+
+	modules, err := s.ModuleVersionSeries("test", "app.terraform.io/test/vpc/aws", time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatalf("ModuleVersionSeries() returned an error: %v", err)
+	}
+	if len(modules) != 3 || modules[0].WorkspaceCount != 2 {
+		t.Errorf("ModuleVersionSeries() returned %+v, expected 3 days with 2 workspaces", modules)
+	}
+
+	if other, err := s.TFVersionSeries("other", time.Time{}, time.Time{}); err != nil || other != nil {
+		t.Errorf("TFVersionSeries() for another organization returned %+v, %v", other, err)
+	}
+}
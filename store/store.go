@@ -0,0 +1,258 @@
+// Package store persists cartographer snapshots in a local SQLite database so that trends such as Terraform version
+// adoption and drift can be charted over time.
+//
+// The store is a separate module so that only programs that import it depend on the SQLite driver.
+package store
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/majesticbeast/cartographer"
+	_ "modernc.org/sqlite"
+)
+
+// timeLayout is the fixed width layout timestamps are stored in, so that they sort and compare correctly as text.
+const timeLayout = "2006-01-02T15:04:05.000000000Z"
+
+// dayLayout is the layout of the days returned by the time series helpers.
+const dayLayout = "2006-01-02"
+
+// ErrSnapshotExists is returned by Append when a snapshot of the same organization taken at the same time has already
+// been stored.
+var ErrSnapshotExists = errors.New("snapshot already stored")
+
+const schema = `
+CREATE TABLE IF NOT EXISTS snapshots (
+	id                INTEGER PRIMARY KEY,
+	organization_name TEXT NOT NULL,
+	taken_at          TEXT NOT NULL,
+	UNIQUE (organization_name, taken_at)
+);
+
+CREATE TABLE IF NOT EXISTS workspaces (
+	id                INTEGER PRIMARY KEY,
+	organization_name TEXT NOT NULL,
+	external_id       TEXT NOT NULL,
+	UNIQUE (organization_name, external_id)
+);
+
+CREATE TABLE IF NOT EXISTS workspace_states (
+	snapshot_id       INTEGER NOT NULL REFERENCES snapshots (id),
+	workspace_id      INTEGER NOT NULL REFERENCES workspaces (id),
+	name              TEXT NOT NULL,
+	project_name      TEXT NOT NULL,
+	terraform_version TEXT NOT NULL,
+	drifted           INTEGER NOT NULL,
+	checks_failed     INTEGER NOT NULL,
+	current_run       TEXT NOT NULL,
+	PRIMARY KEY (snapshot_id, workspace_id)
+);
+
+CREATE TABLE IF NOT EXISTS modules (
+	id     INTEGER PRIMARY KEY,
+	source TEXT NOT NULL UNIQUE
+);
+
+CREATE TABLE IF NOT EXISTS providers (
+	id     INTEGER PRIMARY KEY,
+	source TEXT NOT NULL UNIQUE
+);
+
+CREATE TABLE IF NOT EXISTS workspace_modules (
+	snapshot_id  INTEGER NOT NULL REFERENCES snapshots (id),
+	workspace_id INTEGER NOT NULL REFERENCES workspaces (id),
+	module_id    INTEGER NOT NULL REFERENCES modules (id),
+	version      TEXT NOT NULL,
+	PRIMARY KEY (snapshot_id, workspace_id, module_id, version)
+);
+
+CREATE TABLE IF NOT EXISTS workspace_providers (
+	snapshot_id  INTEGER NOT NULL REFERENCES snapshots (id),
+	workspace_id INTEGER NOT NULL REFERENCES workspaces (id),
+	provider_id  INTEGER NOT NULL REFERENCES providers (id),
+	version      TEXT NOT NULL,
+	PRIMARY KEY (snapshot_id, workspace_id, provider_id, version)
+);
+`
+
+// Store is a SQLite database of snapshots.
+type Store struct {
+	db *sql.DB
+}
+
+// Open opens the SQLite database at path, creating it and its schema if needed.
+func Open(path string) (*Store, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+	// SQLite allows a single writer, so a single connection avoids busy errors between appends.
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec("PRAGMA foreign_keys = ON"); err != nil {
+		db.Close()
+		return nil, err
+	}
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("create schema: %w", err)
+	}
+
+	return &Store{db: db}, nil
+}
+
+// Close closes the database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// SnapshotInfo identifies a stored snapshot.
+type SnapshotInfo struct {
+	Id               int64     `json:"id"`
+	OrganizationName string    `json:"organization-name"`
+	TakenAt          time.Time `json:"taken-at"`
+}
+
+// Append stores a snapshot, adding workspaces, modules and providers not seen before. It returns the ID of the stored
+// snapshot, or ErrSnapshotExists if the snapshot was already stored. Module and provider usage is linked to workspaces
+// by name, and usage by workspaces missing from the snapshot is skipped.
+func (s *Store) Append(snapshot *cartographer.Snapshot) (int64, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	org := snapshot.OrganizationName
+	result, err := tx.Exec("INSERT OR IGNORE INTO snapshots (organization_name, taken_at) VALUES (?, ?)",
+		org, snapshot.TakenAt.UTC().Format(timeLayout))
+	if err != nil {
+		return 0, err
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return 0, err
+	} else if n == 0 {
+		return 0, ErrSnapshotExists
+	}
+	snapshotId, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	workspaceIds := make(map[string]int64)
+	for _, w := range snapshot.Workspaces {
+		key := w.ExternalId
+		if key == "" {
+			key = w.WorkspaceName
+		}
+		id, err := upsertId(tx, "workspaces", "organization_name = ? AND external_id = ?", org, key)
+		if err != nil {
+			return 0, err
+		}
+		workspaceIds[w.WorkspaceName] = id
+
+		_, err = tx.Exec(`INSERT INTO workspace_states
+			(snapshot_id, workspace_id, name, project_name, terraform_version, drifted, checks_failed, current_run)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			snapshotId, id, w.WorkspaceName, w.ProjectName, w.WorkspaceTerraformVersion, w.Drifted, w.ChecksFailed,
+			w.CurrentRunStatus)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	for _, m := range snapshot.Modules {
+		moduleId, err := upsertId(tx, "modules", "source = ?", m.Source)
+		if err != nil {
+			return 0, err
+		}
+		if err := insertUsage(tx, "workspace_modules", "module_id", snapshotId, moduleId, m.Version,
+			m.WorkspaceNames(), workspaceIds); err != nil {
+			return 0, err
+		}
+	}
+
+	for _, p := range snapshot.Providers {
+		providerId, err := upsertId(tx, "providers", "source = ?", p.Source)
+		if err != nil {
+			return 0, err
+		}
+		if err := insertUsage(tx, "workspace_providers", "provider_id", snapshotId, providerId, p.Version,
+			p.WorkspaceNames(), workspaceIds); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return snapshotId, nil
+}
+
+// upsertId returns the ID of the row in table matching where, inserting it first if it does not exist. The columns
+// of the row are taken from the column names in where, which must be of the form "column = ?" joined by AND.
+func upsertId(tx *sql.Tx, table, where string, args ...interface{}) (int64, error) {
+	var id int64
+	err := tx.QueryRow("SELECT id FROM "+table+" WHERE "+where, args...).Scan(&id)
+	if err == nil {
+		return id, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return 0, err
+	}
+
+	var columns []string
+	for _, clause := range strings.Split(where, " AND ") {
+		columns = append(columns, strings.TrimSuffix(clause, " = ?"))
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ")
+
+	result, err := tx.Exec(fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", table, strings.Join(columns, ", "), placeholders), args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+// insertUsage records that the named workspaces use a version of a module or provider in a snapshot.
+func insertUsage(tx *sql.Tx, table, column string, snapshotId, id int64, version string, workspaces []string,
+	workspaceIds map[string]int64) error {
+	query := fmt.Sprintf("INSERT OR IGNORE INTO %s (snapshot_id, workspace_id, %s, version) VALUES (?, ?, ?, ?)", table, column)
+	for _, name := range workspaces {
+		workspaceId, ok := workspaceIds[name]
+		if !ok {
+			continue
+		}
+		if _, err := tx.Exec(query, snapshotId, workspaceId, id, version); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Snapshots returns the stored snapshots of an organization, oldest first.
+func (s *Store) Snapshots(org string) ([]SnapshotInfo, error) {
+	rows, err := s.db.Query("SELECT id, organization_name, taken_at FROM snapshots WHERE organization_name = ? ORDER BY taken_at", org)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var snapshots []SnapshotInfo
+	for rows.Next() {
+		var info SnapshotInfo
+		var takenAt string
+		if err := rows.Scan(&info.Id, &info.OrganizationName, &takenAt); err != nil {
+			return nil, err
+		}
+		if info.TakenAt, err = time.Parse(timeLayout, takenAt); err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, info)
+	}
+	return snapshots, rows.Err()
+}
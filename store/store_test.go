@@ -0,0 +1,84 @@
+package store
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/majesticbeast/cartographer"
+)
+
+func openTestStore(t *testing.T, path string) *Store {
+	t.Helper()
+
+	s, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() returned an error: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func testSnapshot(takenAt time.Time, networkVersion string, networkDrifted bool) *cartographer.Snapshot {
+	return &cartographer.Snapshot{
+		FormatVersion:    cartographer.SnapshotFormatVersion,
+		OrganizationName: "test",
+		TakenAt:          takenAt,
+		Workspaces: []cartographer.Workspace{
+			{ExternalId: "ws-1", WorkspaceName: "network", WorkspaceTerraformVersion: networkVersion, Drifted: networkDrifted},
+			{ExternalId: "ws-2", WorkspaceName: "app", WorkspaceTerraformVersion: "1.6.0"},
+		},
+		Modules: []cartographer.Module{
+			{Source: "app.terraform.io/test/vpc/aws", Version: "1.0.0", Workspaces: "network,app,deleted"},
+		},
+		Providers: []cartographer.Provider{
+			{Source: "hashicorp/aws", Version: "5.0.0", Workspaces: "network"},
+			{Source: "hashicorp/aws", Version: "4.67.0", Workspaces: "app"},
+		},
+	}
+}
+
+func TestStoreAppend(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.db")
+	s := openTestStore(t, path)
+
+	first := testSnapshot(time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC), "1.5.7", false)
+	if _, err := s.Append(first); err != nil {
+		t.Fatalf("Append() returned an error: %v", err)
+	}
+	if _, err := s.Append(first); !errors.Is(err, ErrSnapshotExists) {
+		t.Errorf("Append() of a stored snapshot returned %v, expected ErrSnapshotExists", err)
+	}
+	if _, err := s.Append(testSnapshot(time.Date(2024, 1, 2, 9, 0, 0, 0, time.UTC), "1.6.0", true)); err != nil {
+		t.Fatalf("Append() returned an error: %v", err)
+	}
+
+	snapshots, err := s.Snapshots("test")
+	if err != nil {
+		t.Fatalf("Snapshots() returned an error: %v", err)
+	}
+	if len(snapshots) != 2 || !snapshots[0].TakenAt.Equal(first.TakenAt) {
+		t.Errorf("Snapshots() returned %+v, expected 2 snapshots starting at %v", snapshots, first.TakenAt)
+	}
+
+	var workspaces, modules int
+	if err := s.db.QueryRow("SELECT COUNT(*) FROM workspaces").Scan(&workspaces); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.db.QueryRow("SELECT COUNT(*) FROM workspace_modules").Scan(&modules); err != nil {
+		t.Fatal(err)
+	}
+	if workspaces != 2 || modules != 4 {
+		t.Errorf("Append() stored %d workspaces and %d module usages, expected 2 and 4", workspaces, modules)
+	}
+
+	s.Close()
+	reopened := openTestStore(t, path)
+	if _, err := reopened.Append(testSnapshot(time.Date(2024, 1, 3, 9, 0, 0, 0, time.UTC), "1.6.0", false)); err != nil {
+		t.Fatalf("Append() after reopening returned an error: %v", err)
+	}
+	if snapshots, err := reopened.Snapshots("test"); err != nil || len(snapshots) != 3 {
+		t.Errorf("Snapshots() after reopening returned %+v, %v, expected 3 snapshots", snapshots, err)
+	}
+}
@@ -0,0 +1,216 @@
+package cartographer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	BelowMinimumVersion TFVersionViolationReason = iota
+	OutsideAllowedConstraint
+	EndOfLifeVersion
+)
+
+// TFVersionViolationReason describes why a Terraform version violates a TFVersionPolicy.
+type TFVersionViolationReason int
+
+func (r TFVersionViolationReason) String() string {
+	return [...]string{"below-minimum-version", "outside-allowed-constraint", "end-of-life"}[r]
+}
+
+// MarshalText encodes the reason as its string form so that it is readable in JSON output.
+func (r TFVersionViolationReason) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+const (
+	ConfiguredTFVersion TFVersionSource = iota
+	StateTFVersion
+)
+
+// TFVersionSource identifies which of a workspace's Terraform versions an issue applies to: the version configured on
+// the workspace or the version that last wrote its state.
+type TFVersionSource int
+
+func (s TFVersionSource) String() string {
+	return [...]string{"workspace-terraform-version", "state-version-terraform-version"}[s]
+}
+
+// MarshalText encodes the source as its string form so that it is readable in JSON output.
+func (s TFVersionSource) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// TFVersionPolicy describes the Terraform versions an organization supports. Empty fields are not enforced.
+type TFVersionPolicy struct {
+	// MinimumVersion is the lowest Terraform version allowed, such as "1.5.0".
+	MinimumVersion string
+	// AllowedConstraint is a Terraform style version constraint every version must satisfy, such as ">= 1.5, < 2.0".
+	AllowedConstraint string
+	// EndOfLife lists versions that are no longer supported from a given date.
+	EndOfLife []TFVersionEndOfLife
+}
+
+// TFVersionEndOfLife marks a Terraform version as unsupported from Date onwards. Version may be a full version such as
+// "1.3.2" or a release line such as "1.3", which covers every patch release of that line.
+type TFVersionEndOfLife struct {
+	Version string    `json:"version"`
+	Date    time.Time `json:"date"`
+}
+
+// TFVersionViolation represents a workspace whose configured or state Terraform version violates a TFVersionPolicy.
+type TFVersionViolation struct {
+	WorkspaceName                string           `json:"workspace-name"`
+	ExternalId                   string           `json:"external-id"`
+	ProjectName                  string           `json:"project-name"`
+	WorkspaceTerraformVersion    string           `json:"workspace-terraform-version"`
+	StateVersionTerraformVersion string           `json:"state-version-terraform-version"`
+	VersionMismatch              bool             `json:"version-mismatch"`
+	Issues                       []TFVersionIssue `json:"issues"`
+}
+
+// TFVersionIssue describes a single policy violation of one of a workspace's Terraform versions.
+type TFVersionIssue struct {
+	Source  TFVersionSource          `json:"source"`
+	Version string                   `json:"version"`
+	Reason  TFVersionViolationReason `json:"reason"`
+	Detail  string                   `json:"detail"`
+}
+
+// EvaluateTFVersionPolicy retrieves every workspace in the organization and evaluates them against the policy at the
+// current time.
+func (c *Cartographer) EvaluateTFVersionPolicy(policy TFVersionPolicy) ([]TFVersionViolation, error) {
+	workspaces, err := c.Workspaces(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return policy.Evaluate(workspaces, time.Now())
+}
+
+// Evaluate returns every workspace whose configured or state Terraform version violates the policy, sorted by workspace
+// name. End-of-life dates are compared against now. Both versions of a workspace are checked independently, and
+// VersionMismatch is set when they disagree. Versions that are empty or are not exact versions, such as "latest" or a
+// constraint, are not evaluated. It returns an error if the policy itself is invalid.
+func (p TFVersionPolicy) Evaluate(workspaces []Workspace, now time.Time) ([]TFVersionViolation, error) {
+	var minimum *semver
+	if p.MinimumVersion != "" {
+		v, err := parseSemver(p.MinimumVersion)
+		if err != nil {
+			return nil, fmt.Errorf("invalid minimum version: %w", err)
+		}
+		minimum = &v
+	}
+
+	var allowed versionConstraint
+	if p.AllowedConstraint != "" {
+		c, err := parseConstraint(p.AllowedConstraint)
+		if err != nil {
+			return nil, err
+		}
+		allowed = c
+	}
+
+	for _, eol := range p.EndOfLife {
+		if _, err := parseSemver(eol.Version); err != nil {
+			return nil, fmt.Errorf("invalid end of life version: %w", err)
+		}
+	}
+
+	var violations []TFVersionViolation
+	for _, w := range workspaces {
+		var issues []TFVersionIssue
+		versions := [...]struct {
+			source  TFVersionSource
+			version string
+		}{
+			{ConfiguredTFVersion, w.WorkspaceTerraformVersion},
+			{StateTFVersion, w.StateVersionTerraformVersion},
+		}
+
+		for _, candidate := range versions {
+			v, err := parseSemver(candidate.version)
+			if err != nil {
+				continue
+			}
+
+			if minimum != nil && v.compare(*minimum) < 0 {
+				issues = append(issues, TFVersionIssue{
+					Source:  candidate.source,
+					Version: candidate.version,
+					Reason:  BelowMinimumVersion,
+					Detail:  fmt.Sprintf("%s is below the minimum version %s", candidate.version, p.MinimumVersion),
+				})
+			}
+
+			if allowed != nil && !allowed.check(v) {
+				issues = append(issues, TFVersionIssue{
+					Source:  candidate.source,
+					Version: candidate.version,
+					Reason:  OutsideAllowedConstraint,
+					Detail:  fmt.Sprintf("%s does not satisfy %q", candidate.version, p.AllowedConstraint),
+				})
+			}
+
+			for _, eol := range p.EndOfLife {
+				if now.Before(eol.Date) || !matchesReleaseLine(v, eol.Version) {
+					continue
+				}
+				issues = append(issues, TFVersionIssue{
+					Source:  candidate.source,
+					Version: candidate.version,
+					Reason:  EndOfLifeVersion,
+					Detail:  fmt.Sprintf("%s reached end of life on %s", eol.Version, eol.Date.Format("2006-01-02")),
+				})
+			}
+		}
+
+		if len(issues) == 0 {
+			continue
+		}
+
+		violations = append(violations, TFVersionViolation{
+			WorkspaceName:                w.WorkspaceName,
+			ExternalId:                   w.ExternalId,
+			ProjectName:                  w.ProjectName,
+			WorkspaceTerraformVersion:    w.WorkspaceTerraformVersion,
+			StateVersionTerraformVersion: w.StateVersionTerraformVersion,
+			VersionMismatch:              tfVersionsDisagree(w),
+			Issues:                       issues,
+		})
+	}
+
+	sort.SliceStable(violations, func(i, j int) bool {
+		return violations[i].WorkspaceName < violations[j].WorkspaceName
+	})
+
+	return violations, nil
+}
+
+// matchesReleaseLine reports whether v belongs to line, which is either a full version or a MAJOR or MAJOR.MINOR
+// prefix.
+func matchesReleaseLine(v semver, line string) bool {
+	l, err := parseSemver(line)
+	if err != nil {
+		return false
+	}
+
+	switch strings.Count(strings.TrimPrefix(line, "v"), ".") {
+	case 0:
+		return v.Major == l.Major
+	case 1:
+		return v.Major == l.Major && v.Minor == l.Minor
+	}
+	return v.compare(l) == 0
+}
+
+// tfVersionsDisagree reports whether the workspace's configured Terraform version differs from the version that last
+// wrote its state. Workspaces without state are never reported as disagreeing.
+func tfVersionsDisagree(w Workspace) bool {
+	if w.StateVersionTerraformVersion == "" || w.WorkspaceTerraformVersion == "" {
+		return false
+	}
+	return compareVersions(w.WorkspaceTerraformVersion, w.StateVersionTerraformVersion) != 0
+}
@@ -0,0 +1,65 @@
+package cartographer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTFVersionPolicyEvaluate(t *testing.T) {
+	policy := TFVersionPolicy{
+		MinimumVersion:    "1.3.0",
+		AllowedConstraint: "< 2.0.0",
+		EndOfLife: []TFVersionEndOfLife{
+			{Version: "1.4", Date: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+			{Version: "1.5", Date: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)},
+		},
+	}
+
+	workspaces := []Workspace{
+		{WorkspaceName: "compliant", WorkspaceTerraformVersion: "1.6.0", StateVersionTerraformVersion: "1.6.0"},
+		{WorkspaceName: "too-old", WorkspaceTerraformVersion: "1.2.9", StateVersionTerraformVersion: "1.2.9"},
+		{WorkspaceName: "state-lags", WorkspaceTerraformVersion: "1.6.0", StateVersionTerraformVersion: "1.4.6"},
+		{WorkspaceName: "eol-upcoming", WorkspaceTerraformVersion: "1.5.7", StateVersionTerraformVersion: ""},
+		{WorkspaceName: "latest", WorkspaceTerraformVersion: "latest", StateVersionTerraformVersion: ""},
+	}
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	violations, err := policy.Evaluate(workspaces, now)
+	if err != nil {
+		t.Fatalf("Evaluate() returned an error: %v", err)
+	}
+
+	if len(violations) != 2 {
+		t.Fatalf("Evaluate() returned %v violations, expected 2: %+v", len(violations), violations)
+	}
+
+	stateLags := violations[0]
+	if stateLags.WorkspaceName != "state-lags" || !stateLags.VersionMismatch {
+		t.Errorf("Evaluate() returned %+v, expected state-lags with a version mismatch", stateLags)
+	}
+	if len(stateLags.Issues) != 1 || stateLags.Issues[0].Source != StateTFVersion || stateLags.Issues[0].Reason != EndOfLifeVersion {
+		t.Errorf("Evaluate() returned issues %+v, expected a single end of life state version issue", stateLags.Issues)
+	}
+
+	tooOld := violations[1]
+	if tooOld.WorkspaceName != "too-old" || tooOld.VersionMismatch {
+		t.Errorf("Evaluate() returned %+v, expected too-old without a version mismatch", tooOld)
+	}
+	if len(tooOld.Issues) != 2 || tooOld.Issues[0].Reason != BelowMinimumVersion || tooOld.Issues[1].Source != StateTFVersion {
+		t.Errorf("Evaluate() returned issues %+v, expected below minimum for both versions", tooOld.Issues)
+	}
+}
+
+func TestTFVersionPolicyEvaluateInvalid(t *testing.T) {
+	policies := []TFVersionPolicy{
+		{MinimumVersion: "one"},
+		{AllowedConstraint: ">= "},
+		{EndOfLife: []TFVersionEndOfLife{{Version: "x"}}},
+	}
+
+	for _, policy := range policies {
+		if _, err := policy.Evaluate(nil, time.Now()); err == nil {
+			t.Errorf("Evaluate() with policy %+v expected an error", policy)
+		}
+	}
+}
@@ -28,11 +28,18 @@ func (c TFVersionFilterType) String() string {
 
 // TFVersions Retrieve a list of Terraform versions across all workspaces in an organization.
 func (c *Cartographer) TFVersions(filters []TFVersionFilter) ([]TFVersion, error) {
+	tfVersions, _, err := c.TFVersionsWithMeta(filters)
+	return tfVersions, err
+}
+
+// TFVersionsWithMeta behaves like TFVersions but also returns the links and pagination metadata of the query.
+func (c *Cartographer) TFVersionsWithMeta(filters []TFVersionFilter) ([]TFVersion, *QueryMeta, error) {
 	var tfVersions []TFVersion
+	var meta *QueryMeta
 
 	baseUrl, err := buildExplorerUrl(c.orgName)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	q := url.Values{}
@@ -48,7 +55,7 @@ func (c *Cartographer) TFVersions(filters []TFVersionFilter) ([]TFVersion, error
 
 	req, err := http.NewRequest("GET", baseUrl.String(), nil)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	req.Header.Set("Authorization", "Bearer "+c.token)
@@ -56,22 +63,36 @@ func (c *Cartographer) TFVersions(filters []TFVersionFilter) ([]TFVersion, error
 	for {
 		res, err := c.client.Do(req)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		if err := checkStatusCode(res); err != nil {
-			return nil, err
+			res.Body.Close()
+			return nil, nil, err
 		}
 
 		var apiResponse tfVersionsApiResponse
-		if err := json.NewDecoder(res.Body).Decode(&apiResponse); err != nil {
-			return nil, err
+		err = json.NewDecoder(res.Body).Decode(&apiResponse)
+		res.Body.Close()
+		if err != nil {
+			return nil, nil, err
 		}
 
 		for _, item := range apiResponse.Data {
-			tfVersions = append(tfVersions, item.Attributes)
+			tfVersions = append(tfVersions, TFVersion{
+				Id:             item.Id,
+				Type:           item.Type,
+				Version:        item.Attributes.Version,
+				WorkspaceCount: item.Attributes.WorkspaceCount,
+				Workspaces:     item.Attributes.Workspaces,
+			})
 		}
 
+		if meta == nil {
+			meta = newQueryMeta(apiResponse.Links, apiResponse.Meta)
+		}
+		meta.PagesFetched++
+
 		if apiResponse.Meta.Pagination.NextPage == nil {
 			break
 		}
@@ -82,23 +103,29 @@ func (c *Cartographer) TFVersions(filters []TFVersionFilter) ([]TFVersion, error
 
 		req.URL, err = url.Parse(apiResponse.Links.Next.(string))
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
-		res.Body.Close()
 
 		preventRateLimiting(apiResponse.Meta.Pagination.TotalPages)
 	}
 
-	return tfVersions, nil
+	return tfVersions, meta, nil
 }
 
 // TFVersion represents a Terraform version.
 type TFVersion struct {
+	Id             string `json:"id"`
+	Type           string `json:"type"`
 	Version        string `json:"version"`
 	WorkspaceCount int    `json:"workspace-count"`
 	Workspaces     string `json:"workspaces"`
 }
 
+// WorkspaceNames returns the names of the workspaces using the Terraform version.
+func (v TFVersion) WorkspaceNames() []string {
+	return splitList(v.Workspaces)
+}
+
 // tfVersionsApiResponse is the response from the Terraform Cloud API for the tf_versions endpoint.
 type tfVersionsApiResponse struct {
 	Data []struct {
@@ -110,21 +137,6 @@ type tfVersionsApiResponse struct {
 		Id   string `json:"id"`
 		Type string `json:"type"`
 	} `json:"data"`
-	Links struct {
-		Self  string      `json:"self"`
-		First string      `json:"first"`
-		Last  string      `json:"last"`
-		Prev  interface{} `json:"prev"`
-		Next  interface{} `json:"next"`
-	} `json:"links"`
-	Meta struct {
-		Pagination struct {
-			CurrentPage int         `json:"current-page"`
-			PageSize    int         `json:"page-size"`
-			NextPage    interface{} `json:"next-page"`
-			PrevPage    interface{} `json:"prev-page"`
-			TotalPages  int         `json:"total-pages"`
-			TotalCount  int         `json:"total-count"`
-		} `json:"pagination"`
-	} `json:"meta"`
+	Links apiLinks `json:"links"`
+	Meta  apiMeta  `json:"meta"`
 }
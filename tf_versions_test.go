@@ -64,6 +64,12 @@ func TestTFVersions(t *testing.T) {
 	}
 
 	tfVersion := tfVersions[0]
+	if tfVersion.Id != "test" {
+		t.Errorf("TFVersions() returned tfVersion with id %v, expected 'test'", tfVersion.Id)
+	}
+	if tfVersion.Type != "test" {
+		t.Errorf("TFVersions() returned tfVersion with type %v, expected 'test'", tfVersion.Type)
+	}
 	if tfVersion.Version != "0.12.0" {
 		t.Errorf("TFVersions() returned tfVersion with version %v, expected 'test'", tfVersion.Version)
 	}
@@ -0,0 +1,90 @@
+package cartographer
+
+import (
+	"sort"
+)
+
+// UnusedModules builds an UnusedModuleReport for the organization by cross-referencing the private registry modules
+// with the module usage reported by the Explorer.
+func (c *Cartographer) UnusedModules() (*UnusedModuleReport, error) {
+	registryModules, err := c.PrivateRegistryModules()
+	if err != nil {
+		return nil, err
+	}
+
+	modules, err := c.Modules(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewUnusedModuleReport(registryModules, modules), nil
+}
+
+// NewUnusedModuleReport cross-references private registry modules with Explorer module usage by source. Modules no
+// workspace uses are reported as unused. For modules that are in use, the report lists those whose latest version is
+// not used by any workspace and every published version other than the latest that no workspace uses.
+func NewUnusedModuleReport(registryModules []PrivateRegistryModule, modules []Module) *UnusedModuleReport {
+	bySource := make(map[string]PrivateRegistryModule)
+	for _, rm := range registryModules {
+		bySource[rm.Source()] = rm
+	}
+
+	report := &UnusedModuleReport{}
+	for _, om := range NewOutdatedModuleReport(registryModules, modules).Modules {
+		rm := bySource[om.Source]
+
+		if len(om.Versions) == 0 {
+			report.Unused = append(report.Unused, rm)
+			continue
+		}
+
+		inUse := make(map[string]bool)
+		for _, v := range om.Versions {
+			inUse[v.Version] = true
+		}
+
+		// Modules without a published version have no latest version to be behind.
+		if om.LatestVersion != "" && !inUse[om.LatestVersion] {
+			report.LatestUnused = append(report.LatestUnused, om)
+		}
+
+		var deprecatable []string
+		for _, v := range rm.Versions {
+			if v.Status != "ok" || v.Version == om.LatestVersion || inUse[v.Version] {
+				continue
+			}
+			deprecatable = append(deprecatable, v.Version)
+		}
+		if len(deprecatable) == 0 {
+			continue
+		}
+
+		sort.Slice(deprecatable, func(i, j int) bool {
+			return compareVersions(deprecatable[i], deprecatable[j]) > 0
+		})
+		report.Deprecatable = append(report.Deprecatable, DeprecatableModuleVersions{
+			Source:        om.Source,
+			LatestVersion: om.LatestVersion,
+			Versions:      deprecatable,
+		})
+	}
+
+	return report
+}
+
+// UnusedModuleReport lists the private registry modules and module versions that are not consumed by any workspace.
+type UnusedModuleReport struct {
+	// Unused are the registry modules that no workspace uses.
+	Unused []PrivateRegistryModule `json:"unused"`
+	// LatestUnused are the modules that are only used at versions older than the latest published version.
+	LatestUnused []OutdatedModule `json:"latest_unused"`
+	// Deprecatable are the published versions of used modules that no workspace uses, excluding the latest version.
+	Deprecatable []DeprecatableModuleVersions `json:"deprecatable"`
+}
+
+// DeprecatableModuleVersions lists the published versions of a module that can be deprecated as no workspace uses them.
+type DeprecatableModuleVersions struct {
+	Source        string   `json:"source"`
+	LatestVersion string   `json:"latest_version"`
+	Versions      []string `json:"versions"`
+}
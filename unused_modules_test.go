@@ -0,0 +1,70 @@
+package cartographer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewUnusedModuleReport(t *testing.T) {
+	registryModules := []PrivateRegistryModule{
+		{
+			Name:         "vpc",
+			Namespace:    "myOrgName",
+			Provider:     "aws",
+			RegistryName: "private",
+			Versions: []PrivateRegistryModuleVersion{
+				{Version: "1.3.0", Status: "ok"},
+				{Version: "1.2.0", Status: "ok"},
+				{Version: "1.1.0", Status: "ok"},
+				{Version: "1.0.0", Status: "errored"},
+			},
+			LatestVersion: "1.3.0",
+		},
+		{
+			Name:          "iam",
+			Namespace:     "myOrgName",
+			Provider:      "aws",
+			RegistryName:  "private",
+			Versions:      []PrivateRegistryModuleVersion{{Version: "0.1.0", Status: "ok"}},
+			LatestVersion: "0.1.0",
+		},
+		{
+			Name:          "tags",
+			Namespace:     "myOrgName",
+			Provider:      "null",
+			RegistryName:  "private",
+			Versions:      []PrivateRegistryModuleVersion{{Version: "1.0.0", Status: "ok"}},
+			LatestVersion: "1.0.0",
+		},
+		{
+			Name:         "dns",
+			Namespace:    "myOrgName",
+			Provider:     "aws",
+			RegistryName: "private",
+			Versions:     []PrivateRegistryModuleVersion{{Version: "0.1.0", Status: "errored"}},
+		},
+	}
+
+	modules := []Module{
+		{Name: "vpc", Source: "app.terraform.io/myOrgName/vpc/aws", Version: "1.2.0", Workspaces: "ws-a"},
+		{Name: "tags", Source: "app.terraform.io/myOrgName/tags/null", Version: "1.0.0", Workspaces: "ws-a,ws-b"},
+		{Name: "dns", Source: "app.terraform.io/myOrgName/dns/aws", Version: "0.1.0", Workspaces: "ws-b"},
+	}
+
+	report := NewUnusedModuleReport(registryModules, modules)
+
+	if len(report.Unused) != 1 || report.Unused[0].Name != "iam" {
+		t.Errorf("NewUnusedModuleReport() returned unused %+v, expected only iam", report.Unused)
+	}
+
+	if len(report.LatestUnused) != 1 || report.LatestUnused[0].Name != "vpc" {
+		t.Errorf("NewUnusedModuleReport() returned latest unused %+v, expected only vpc and not dns without a published version", report.LatestUnused)
+	}
+
+	expected := []DeprecatableModuleVersions{
+		{Source: "app.terraform.io/myOrgName/vpc/aws", LatestVersion: "1.3.0", Versions: []string{"1.1.0"}},
+	}
+	if !reflect.DeepEqual(report.Deprecatable, expected) {
+		t.Errorf("NewUnusedModuleReport() returned deprecatable %+v, expected %+v", report.Deprecatable, expected)
+	}
+}
@@ -0,0 +1,234 @@
+package cartographer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// semver is a parsed semantic version. Build metadata is dropped as it has no bearing on precedence.
+type semver struct {
+	Major      int
+	Minor      int
+	Patch      int
+	Prerelease string
+}
+
+// parseSemver parses a version string such as "1.2.3", "v1.2.3" or "1.2.3-beta.1+build". Missing minor and patch
+// components are treated as zero so that "1.2" parses as "1.2.0".
+func parseSemver(v string) (semver, error) {
+	s := strings.TrimPrefix(strings.TrimSpace(v), "v")
+	if i := strings.Index(s, "+"); i >= 0 {
+		s = s[:i]
+	}
+
+	var sv semver
+	if i := strings.Index(s, "-"); i >= 0 {
+		sv.Prerelease = s[i+1:]
+		s = s[:i]
+		if sv.Prerelease == "" {
+			return semver{}, fmt.Errorf("invalid version %q: empty prerelease", v)
+		}
+	}
+
+	parts := strings.Split(s, ".")
+	if len(parts) == 0 || len(parts) > 3 {
+		return semver{}, fmt.Errorf("invalid version %q", v)
+	}
+
+	nums := [3]int{}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return semver{}, fmt.Errorf("invalid version %q", v)
+		}
+		nums[i] = n
+	}
+	sv.Major, sv.Minor, sv.Patch = nums[0], nums[1], nums[2]
+
+	return sv, nil
+}
+
+// String returns the version in MAJOR.MINOR.PATCH[-PRERELEASE] form.
+func (v semver) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+	return s
+}
+
+// compare returns -1, 0 or 1 depending on whether v has lower, equal or higher precedence than o.
+func (v semver) compare(o semver) int {
+	for _, d := range [...]int{v.Major - o.Major, v.Minor - o.Minor, v.Patch - o.Patch} {
+		if d < 0 {
+			return -1
+		}
+		if d > 0 {
+			return 1
+		}
+	}
+	return comparePrerelease(v.Prerelease, o.Prerelease)
+}
+
+// comparePrerelease compares two prerelease strings following the semantic versioning precedence rules. A version
+// without a prerelease has higher precedence than one with a prerelease.
+func comparePrerelease(a, b string) int {
+	if a == b {
+		return 0
+	}
+	if a == "" {
+		return 1
+	}
+	if b == "" {
+		return -1
+	}
+
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		an, aErr := strconv.Atoi(as[i])
+		bn, bErr := strconv.Atoi(bs[i])
+		switch {
+		case aErr == nil && bErr == nil:
+			if an != bn {
+				if an < bn {
+					return -1
+				}
+				return 1
+			}
+		case aErr == nil:
+			return -1
+		case bErr == nil:
+			return 1
+		default:
+			if c := strings.Compare(as[i], bs[i]); c != 0 {
+				return c
+			}
+		}
+	}
+
+	switch {
+	case len(as) < len(bs):
+		return -1
+	case len(as) > len(bs):
+		return 1
+	}
+	return 0
+}
+
+// compareVersions compares two version strings. Versions that cannot be parsed sort below all valid versions and are
+// compared lexically amongst themselves.
+func compareVersions(a, b string) int {
+	av, aErr := parseSemver(a)
+	bv, bErr := parseSemver(b)
+	switch {
+	case aErr == nil && bErr == nil:
+		return av.compare(bv)
+	case aErr == nil:
+		return 1
+	case bErr == nil:
+		return -1
+	}
+	return strings.Compare(a, b)
+}
+
+// versionConstraint is a parsed Terraform style version constraint such as ">= 1.5.0, < 2.0.0" or "~> 1.6". A version
+// satisfies the constraint when it satisfies every clause.
+type versionConstraint []constraintClause
+
+// constraintClause is a single operator and version pair within a version constraint. Parts records how many version
+// components were written, which determines the upper bound of the pessimistic "~>" operator.
+type constraintClause struct {
+	Operator string
+	Version  semver
+	Parts    int
+}
+
+// parseConstraint parses a comma separated list of version constraints. Supported operators are =, !=, >, >=, <, <=
+// and ~>. A clause without an operator is treated as =.
+func parseConstraint(s string) (versionConstraint, error) {
+	var constraint versionConstraint
+	for _, clause := range strings.Split(s, ",") {
+		clause = strings.TrimSpace(clause)
+		if clause == "" {
+			return nil, fmt.Errorf("invalid version constraint %q: empty clause", s)
+		}
+
+		op := "="
+		for _, candidate := range [...]string{"~>", ">=", "<=", "!=", ">", "<", "="} {
+			if strings.HasPrefix(clause, candidate) {
+				op = candidate
+				clause = strings.TrimSpace(strings.TrimPrefix(clause, candidate))
+				break
+			}
+		}
+
+		v, err := parseSemver(clause)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version constraint %q: %w", s, err)
+		}
+
+		parts := strings.Count(strings.SplitN(strings.SplitN(clause, "-", 2)[0], "+", 2)[0], ".") + 1
+		constraint = append(constraint, constraintClause{Operator: op, Version: v, Parts: parts})
+	}
+	return constraint, nil
+}
+
+// check reports whether v satisfies every clause of the constraint. As in Terraform, a prerelease only satisfies a
+// constraint that explicitly names a prerelease of the same version, so "~> 1.6" does not match 2.0.0-alpha and
+// ">= 1.5.0" does not match 1.6.0-beta1.
+func (c versionConstraint) check(v semver) bool {
+	if v.Prerelease != "" && !c.namesPrerelease(v) {
+		return false
+	}
+	for _, clause := range c {
+		if !clause.check(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// namesPrerelease reports whether a clause of the constraint is a prerelease of the same major, minor and patch
+// version as v.
+func (c versionConstraint) namesPrerelease(v semver) bool {
+	for _, clause := range c {
+		cv := clause.Version
+		if cv.Prerelease != "" && cv.Major == v.Major && cv.Minor == v.Minor && cv.Patch == v.Patch {
+			return true
+		}
+	}
+	return false
+}
+
+// check reports whether v satisfies the clause.
+func (c constraintClause) check(v semver) bool {
+	cmp := v.compare(c.Version)
+	switch c.Operator {
+	case "=":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case "~>":
+		if cmp < 0 {
+			return false
+		}
+		switch c.Parts {
+		case 1:
+			return true
+		case 2:
+			return v.compare(semver{Major: c.Version.Major + 1}) < 0
+		default:
+			return v.compare(semver{Major: c.Version.Major, Minor: c.Version.Minor + 1}) < 0
+		}
+	}
+	return false
+}
@@ -0,0 +1,100 @@
+package cartographer
+
+import "testing"
+
+func TestParseSemver(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected semver
+		wantErr  bool
+	}{
+		{input: "1.2.3", expected: semver{Major: 1, Minor: 2, Patch: 3}},
+		{input: "v0.12.31", expected: semver{Major: 0, Minor: 12, Patch: 31}},
+		{input: "1.5", expected: semver{Major: 1, Minor: 5}},
+		{input: "2.0.0-beta.1+build.5", expected: semver{Major: 2, Prerelease: "beta.1"}},
+		{input: "latest", wantErr: true},
+		{input: "1.2.3.4", wantErr: true},
+		{input: "1.2.3-", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseSemver(tt.input)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseSemver(%q) expected an error, got %v", tt.input, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseSemver(%q) returned an error: %v", tt.input, err)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("parseSemver(%q) returned %+v, expected %+v", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{a: "1.0.0", b: "1.0.0", expected: 0},
+		{a: "1.10.0", b: "1.9.0", expected: 1},
+		{a: "0.9.9", b: "1.0.0", expected: -1},
+		{a: "1.0.0-alpha", b: "1.0.0", expected: -1},
+		{a: "1.0.0-alpha.2", b: "1.0.0-alpha.10", expected: -1},
+		{a: "1.0.0-alpha.1", b: "1.0.0-alpha", expected: 1},
+		{a: "1.0.0-1", b: "1.0.0-alpha", expected: -1},
+		{a: "garbage", b: "0.0.1", expected: -1},
+	}
+
+	for _, tt := range tests {
+		if got := compareVersions(tt.a, tt.b); got != tt.expected {
+			t.Errorf("compareVersions(%q, %q) returned %d, expected %d", tt.a, tt.b, got, tt.expected)
+		}
+	}
+}
+
+func TestVersionConstraint(t *testing.T) {
+	tests := []struct {
+		constraint string
+		version    string
+		expected   bool
+	}{
+		{constraint: ">= 1.5.0, < 2.0.0", version: "1.7.3", expected: true},
+		{constraint: ">= 1.5.0, < 2.0.0", version: "2.0.0", expected: false},
+		{constraint: "~> 1.6", version: "1.9.0", expected: true},
+		{constraint: "~> 1.6", version: "2.0.0", expected: false},
+		{constraint: "~> 1.6.2", version: "1.6.9", expected: true},
+		{constraint: "~> 1.6.2", version: "1.7.0", expected: false},
+		{constraint: "1.5.7", version: "1.5.7", expected: true},
+		{constraint: "!= 1.5.7", version: "1.5.7", expected: false},
+		{constraint: "> 1.5.7", version: "1.5.8-beta1", expected: false},
+		{constraint: "~> 1.6", version: "2.0.0-alpha", expected: false},
+		{constraint: "~> 1.6", version: "1.7.0-rc1", expected: false},
+		{constraint: ">= 1.5.8-beta1", version: "1.5.8-beta2", expected: true},
+		{constraint: ">= 1.5.8-beta1, < 2.0.0", version: "1.5.8-rc1", expected: true},
+		{constraint: ">= 1.5.8-beta1", version: "1.5.9-beta1", expected: false},
+		{constraint: "= 2.0.0-alpha", version: "2.0.0-alpha", expected: true},
+	}
+
+	for _, tt := range tests {
+		c, err := parseConstraint(tt.constraint)
+		if err != nil {
+			t.Errorf("parseConstraint(%q) returned an error: %v", tt.constraint, err)
+			continue
+		}
+		v, _ := parseSemver(tt.version)
+		if got := c.check(v); got != tt.expected {
+			t.Errorf("%q.check(%q) returned %v, expected %v", tt.constraint, tt.version, got, tt.expected)
+		}
+	}
+
+	for _, invalid := range []string{"", ">= 1.0,", ">= one"} {
+		if _, err := parseConstraint(invalid); err == nil {
+			t.Errorf("parseConstraint(%q) expected an error", invalid)
+		}
+	}
+}
@@ -77,11 +77,18 @@ func (w WorkspaceFilterType) String() string {
 
 // Workspaces Retrieve a list of workspaces in an organization.
 func (c *Cartographer) Workspaces(filters []WorkspaceFilter) ([]Workspace, error) {
+	workspaces, _, err := c.WorkspacesWithMeta(filters)
+	return workspaces, err
+}
+
+// WorkspacesWithMeta behaves like Workspaces but also returns the links and pagination metadata of the query.
+func (c *Cartographer) WorkspacesWithMeta(filters []WorkspaceFilter) ([]Workspace, *QueryMeta, error) {
 	var workspaces []Workspace
+	var meta *QueryMeta
 
 	baseUrl, err := buildExplorerUrl(c.orgName)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	q := url.Values{}
@@ -97,7 +104,7 @@ func (c *Cartographer) Workspaces(filters []WorkspaceFilter) ([]Workspace, error
 
 	req, err := http.NewRequest("GET", baseUrl.String(), nil)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	req.Header.Set("Authorization", "Bearer "+c.token)
@@ -105,16 +112,19 @@ func (c *Cartographer) Workspaces(filters []WorkspaceFilter) ([]Workspace, error
 	for {
 		res, err := c.client.Do(req)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		if err := checkStatusCode(res); err != nil {
-			return nil, err
+			res.Body.Close()
+			return nil, nil, err
 		}
 
 		var apiResponse workspacesApiResponse
-		if err := json.NewDecoder(res.Body).Decode(&apiResponse); err != nil {
-			return nil, err
+		err = json.NewDecoder(res.Body).Decode(&apiResponse)
+		res.Body.Close()
+		if err != nil {
+			return nil, nil, err
 		}
 
 		for _, item := range apiResponse.Data {
@@ -132,6 +142,8 @@ func (c *Cartographer) Workspaces(filters []WorkspaceFilter) ([]Workspace, error
 			}
 
 			workspace := Workspace{
+				Id:                           item.Id,
+				Type:                         item.Type,
 				AllChecksSucceeded:           item.Attributes.AllChecksSucceeded,
 				ChecksErrored:                item.Attributes.ChecksErrored,
 				ChecksFailed:                 item.Attributes.ChecksFailed,
@@ -161,6 +173,11 @@ func (c *Cartographer) Workspaces(filters []WorkspaceFilter) ([]Workspace, error
 			workspaces = append(workspaces, workspace)
 		}
 
+		if meta == nil {
+			meta = newQueryMeta(apiResponse.Links, apiResponse.Meta)
+		}
+		meta.PagesFetched++
+
 		if apiResponse.Meta.Pagination.NextPage == nil {
 			break
 		}
@@ -171,14 +188,13 @@ func (c *Cartographer) Workspaces(filters []WorkspaceFilter) ([]Workspace, error
 
 		req.URL, err = url.Parse(apiResponse.Links.Next.(string))
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
-		res.Body.Close()
 
 		preventRateLimiting(apiResponse.Meta.Pagination.TotalPages)
 	}
 
-	return workspaces, nil
+	return workspaces, meta, nil
 }
 
 // WorkspaceModule represents a module in the Workspace Struct
@@ -189,6 +205,8 @@ type WorkspaceModule struct {
 
 // Workspace represents a workspace in Terraform Cloud
 type Workspace struct {
+	Id                           string            `json:"id"`
+	Type                         string            `json:"type"`
 	AllChecksSucceeded           bool              `json:"all-checks-succeeded"`
 	ChecksErrored                int               `json:"checks-errored"`
 	ChecksFailed                 int               `json:"checks-failed"`
@@ -216,6 +234,30 @@ type Workspace struct {
 	WorkspaceUpdatedAt           time.Time         `json:"workspace-updated-at"`
 }
 
+// ProviderNames returns the names of the providers used by the workspace.
+func (w Workspace) ProviderNames() []string {
+	return splitList(w.Providers)
+}
+
+// URL returns the Terraform Cloud web UI URL for the workspace.
+func (w Workspace) URL() string {
+	return fmt.Sprintf("%s/%s/workspaces/%s", webBaseUrl, url.PathEscape(w.OrganizationName), url.PathEscape(w.WorkspaceName))
+}
+
+// ProjectURL returns the Terraform Cloud web UI URL for the project the workspace belongs to.
+func (w Workspace) ProjectURL() string {
+	return fmt.Sprintf("%s/%s/projects/%s", webBaseUrl, url.PathEscape(w.OrganizationName), url.PathEscape(w.ProjectExternalId))
+}
+
+// CurrentRunURL returns the Terraform Cloud web UI URL for the workspace's current run. It returns an empty string if
+// the workspace has no current run.
+func (w Workspace) CurrentRunURL() string {
+	if w.CurrentRunExternalId == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/runs/%s", w.URL(), url.PathEscape(w.CurrentRunExternalId))
+}
+
 // workspacesApiResponse is the response from the Terraform Cloud API
 type workspacesApiResponse struct {
 	Data []struct {
@@ -249,21 +291,6 @@ type workspacesApiResponse struct {
 		Id   string `json:"id"`
 		Type string `json:"type"`
 	} `json:"data"`
-	Links struct {
-		Self  string      `json:"self"`
-		First string      `json:"first"`
-		Last  string      `json:"last"`
-		Prev  interface{} `json:"prev"`
-		Next  interface{} `json:"next"`
-	} `json:"links"`
-	Meta struct {
-		Pagination struct {
-			CurrentPage int         `json:"current-page"`
-			PageSize    int         `json:"page-size"`
-			NextPage    interface{} `json:"next-page"`
-			PrevPage    interface{} `json:"prev-page"`
-			TotalPages  int         `json:"total-pages"`
-			TotalCount  int         `json:"total-count"`
-		} `json:"pagination"`
-	} `json:"meta"`
+	Links apiLinks `json:"links"`
+	Meta  apiMeta  `json:"meta"`
 }
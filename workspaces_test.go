@@ -86,6 +86,12 @@ func TestWorkspaces(t *testing.T) {
 	}
 
 	workspace := workspaces[0]
+	if workspace.Id != "test" {
+		t.Errorf("Workspaces() returned workspace with id %v, expected 'test'", workspace.Id)
+	}
+	if workspace.Type != "test" {
+		t.Errorf("Workspaces() returned workspace with type %v, expected 'test'", workspace.Type)
+	}
 	if workspace.WorkspaceName != "testWorkspace" {
 		t.Errorf("Workspaces() returned workspace with name %v, expected 'testWorkspace'", workspace.WorkspaceName)
 	}
@@ -99,3 +105,27 @@ func TestWorkspaces(t *testing.T) {
 		t.Errorf("Workspaces() returned workspace with module count %v, expected '3'", workspace.ModuleCount)
 	}
 }
+
+func TestWorkspaceURL(t *testing.T) {
+	workspace := Workspace{
+		OrganizationName:     "myOrgName",
+		WorkspaceName:        "testWorkspace",
+		ProjectExternalId:    "prj-123",
+		CurrentRunExternalId: "run-456",
+	}
+
+	if got := workspace.URL(); got != "https://app.terraform.io/app/myOrgName/workspaces/testWorkspace" {
+		t.Errorf("URL() returned %v", got)
+	}
+	if got := workspace.ProjectURL(); got != "https://app.terraform.io/app/myOrgName/projects/prj-123" {
+		t.Errorf("ProjectURL() returned %v", got)
+	}
+	if got := workspace.CurrentRunURL(); got != "https://app.terraform.io/app/myOrgName/workspaces/testWorkspace/runs/run-456" {
+		t.Errorf("CurrentRunURL() returned %v", got)
+	}
+
+	workspace.CurrentRunExternalId = ""
+	if got := workspace.CurrentRunURL(); got != "" {
+		t.Errorf("CurrentRunURL() returned %v, expected empty string", got)
+	}
+}